@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal/aptos"
+	"github.com/naiba/nb/model"
+)
+
+var aptosCmd = &cli.Command{
+	Name:  "aptos",
+	Usage: "Aptos helper.",
+	Commands: []*cli.Command{
+		aptosVanityCmd,
+	},
+}
+
+var aptosVanityCmd = &cli.Command{
+	Name:    "vanity",
+	Aliases: []string{"v"},
+	Usage:   "Generate a vanity Aptos address (--keystore writes plaintext .key files)",
+	Flags:   model.VanityFlags(),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
+		if err != nil {
+			return err
+		}
+		return aptos.VanityAddress(config)
+	},
+}
+
+func init() {
+	rootCmd.Commands = append(rootCmd.Commands, aptosCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/bitcoin"
+	"github.com/naiba/nb/model"
+)
+
+var bitcoinCmd = &cli.Command{
+	Name:  "bitcoin",
+	Usage: "Bitcoin helper.",
+	Commands: []*cli.Command{
+		bitcoinVanityCmd,
+	},
+}
+
+var bitcoinVanityCmd = &cli.Command{
+	Name:    "vanity",
+	Aliases: []string{"v"},
+	Usage:   "Generate a vanity Bitcoin address (--keystore writes plaintext .wif files)",
+	Flags: append(model.VanityFlags(), &cli.StringFlag{
+		Name:  "type",
+		Usage: "Address type: p2pkh (legacy 1...), p2wpkh (segwit bc1q...), p2tr (taproot bc1p...).",
+		Value: bitcoin.AddressTypeP2PKH,
+	}),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		alphabet := bech32.Charset
+		if cmd.String("type") == bitcoin.AddressTypeP2PKH {
+			alphabet = model.Base58Alphabet
+		}
+		config, err := model.ParseVanityConfig(cmd, alphabet)
+		if err != nil {
+			return err
+		}
+		return bitcoin.VanityAddress(config, cmd.String("type"))
+	},
+}
+
+func init() {
+	rootCmd.Commands = append(rootCmd.Commands, bitcoinCmd)
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/naiba/nb/internal"
 	"github.com/naiba/nb/model"
 	"github.com/naiba/nb/singleton"
 )
@@ -52,6 +58,21 @@ func GetProxyConfig(proxyName string) (*ProxyConfig, error) {
 	return config, nil
 }
 
+// GetProxyEnv returns the all_proxy/http_proxy/https_proxy variables for a
+// proxy by name, or nil without one.
+func GetProxyEnv(proxyName string) ([]string, error) {
+	proxyConfig, err := GetProxyConfig(proxyName)
+	if err != nil || proxyConfig == nil {
+		return nil, err
+	}
+	env := []string{fmt.Sprintf("all_proxy=socks5h://%s:%s", proxyConfig.SocksHost, proxyConfig.SocksPort)}
+	if proxyConfig.HttpHost != "" {
+		env = append(env, fmt.Sprintf("http_proxy=http://%s:%s", proxyConfig.HttpHost, proxyConfig.HttpPort))
+		env = append(env, fmt.Sprintf("https_proxy=http://%s:%s", proxyConfig.HttpHost, proxyConfig.HttpPort))
+	}
+	return env, nil
+}
+
 // GetSSHServerConfig retrieves SSH server configuration by name
 func GetSSHServerConfig(sshServerName string) (*model.SSHAccount, error) {
 	if sshServerName == "" {
@@ -95,6 +116,101 @@ func GetGitSSHCommandEnv(user string, proxyName string) (*model.GitAccount, []st
 	}, nil
 }
 
+// GitUserByEmail returns the configured account with email, as written to
+// a repository by `nb git setup`, or "".
+func GitUserByEmail(email string) string {
+	if email == "" || singleton.Config == nil {
+		return ""
+	}
+	names := make([]string, 0, len(singleton.Config.Git))
+	for name := range singleton.Config.Git {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if strings.EqualFold(singleton.Config.Git[name].Email, email) {
+			return name
+		}
+	}
+	return ""
+}
+
+// ResolveGitUser returns user when set; otherwise the account of the first
+// git rule matching remoteURL or dir, along with that rule.
+func ResolveGitUser(user, remoteURL, dir string) (string, *model.GitRule) {
+	if user != "" || singleton.Config == nil || len(singleton.Config.GitRules) == 0 {
+		return user, nil
+	}
+	home, _ := os.UserHomeDir()
+	rule := singleton.Config.MatchGitRule(remoteURL, dir, home)
+	if rule == nil {
+		return "", nil
+	}
+	return rule.Account, rule
+}
+
+// resolveGitUserHere resolves user against the current repository's origin
+// remote and the working directory, noting an auto-selected account on
+// stderr.
+func resolveGitUserHere(user string) string {
+	if user != "" || singleton.Config == nil || len(singleton.Config.GitRules) == 0 {
+		return user
+	}
+	dir, _ := os.Getwd()
+	account, rule := ResolveGitUser(user, currentGitRemote(), dir)
+	if rule != nil {
+		fmt.Fprintf(os.Stderr, "nb: using git account %s (%s)\n", account, describeGitRule(rule))
+	}
+	return account
+}
+
+// currentGitRemote returns the origin URL of the repository around the
+// working directory, or "" outside one.
+func currentGitRemote() string {
+	out, err := internal.ExecuteInHostWithOutput(nil, "git", "config", "--get", "remote.origin.url")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitOutput runs git with args and returns its trimmed output. A failure
+// carries git's stderr.
+func gitOutput(env []string, args ...string) (string, error) {
+	out, err := internal.ExecuteArgsInHostWithOutput(env, "git", args...)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func describeGitRule(rule *model.GitRule) string {
+	var parts []string
+	if rule.Remote != "" {
+		parts = append(parts, "remote "+rule.Remote)
+	}
+	if rule.Path != "" {
+		parts = append(parts, "path "+rule.Path)
+	}
+	return "rule " + strings.Join(parts, ", ")
+}
+
+// expandUserPath expands a leading ~ to the home directory.
+func expandUserPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
 func ReplaceRemotePath(slice []string, server model.SSHAccount) error {
 	var replaced bool
 	for i := 0; i < len(slice); i++ {
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/cosmos"
+	"github.com/naiba/nb/model"
+)
+
+var cosmosCmd = &cli.Command{
+	Name:  "cosmos",
+	Usage: "Cosmos SDK helper.",
+	Commands: []*cli.Command{
+		cosmosVanityCmd,
+	},
+}
+
+var cosmosVanityCmd = &cli.Command{
+	Name:    "vanity",
+	Aliases: []string{"v"},
+	Usage:   "Generate a vanity Cosmos SDK address for any bech32 prefix (--keystore writes plaintext .hex files)",
+	Flags: append(model.VanityFlags(), &cli.StringFlag{
+		Name:  "hrp",
+		Usage: "Bech32 human-readable prefix, e.g. cosmos, osmo, celestia. Chains with Ethereum-style keys (Evmos, Injective) derive addresses differently and are not supported.",
+		Value: "cosmos",
+	}),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, bech32.Charset)
+		if err != nil {
+			return err
+		}
+		return cosmos.VanityAddress(config, cmd.String("hrp"))
+	},
+}
+
+func init() {
+	rootCmd.Commands = append(rootCmd.Commands, cosmosCmd)
+}
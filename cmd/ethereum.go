@@ -3,17 +3,24 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"math"
 	"math/big"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
 
 	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/internal/tron"
 	"github.com/naiba/nb/model"
 )
 
@@ -28,6 +35,8 @@ var ethereumCmd = &cli.Command{
 		ethereumVanityCmd,
 		ethereumVanityCreate1Cmd,
 		ethereumVanityCreate2Cmd,
+		ethereumVanityCreate3Cmd,
+		ethereumCombineCmd,
 		timestampToBlockNumberCmd,
 	},
 }
@@ -35,13 +44,16 @@ var ethereumCmd = &cli.Command{
 var ethereumVanityCmd = &cli.Command{
 	Name:  "vanity",
 	Usage: "Generate vanity address.",
-	Flags: model.VanityFlags(),
+	Flags: append(model.VanitySecpFlags(), model.VanityMnemonicFlags()...),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		config, err := model.ParseVanityConfig(cmd)
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
 		if err != nil {
 			return err
 		}
 
+		if cmd.Bool("mnemonic") {
+			return ethereum.VanityMnemonicAddress(config, cmd.Int("words"))
+		}
 		return ethereum.VanityAddress(config)
 	},
 }
@@ -49,15 +61,21 @@ var ethereumVanityCmd = &cli.Command{
 var ethereumVanityCreate1Cmd = &cli.Command{
 	Name:    "vanity-create1",
 	Aliases: []string{"vc1"},
-	Usage:   "Generate vanity CREATE1 contract address (first deployment, nonce=0).",
-	Flags:   model.VanityFlags(),
+	Usage:   "Generate vanity CREATE1 contract address (first deployment, nonce=0, or up to --max-nonce).",
+	Flags:   model.VanityCreate1Flags(),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		config, err := model.ParseVanityConfig(cmd)
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
 		if err != nil {
 			return err
 		}
 
-		return ethereum.VanityCreate1Address(config)
+		if deployer := cmd.String("deployer"); deployer != "" {
+			if !cmd.IsSet("max-nonce") {
+				return fmt.Errorf("--deployer requires --max-nonce")
+			}
+			return ethereum.ScanCreate1Nonces(config, deployer, cmd.Uint64("max-nonce"))
+		}
+		return ethereum.VanityCreate1Address(config, cmd.Uint64("max-nonce"))
 	},
 }
 
@@ -67,7 +85,7 @@ var ethereumVanityCreate2Cmd = &cli.Command{
 	Usage:   "Generate vanity CREATE2 address.",
 	Flags:   model.VanityCreate2Flags(),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		config, err := model.ParseVanityConfig(cmd)
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
 		if err != nil {
 			return err
 		}
@@ -77,11 +95,119 @@ var ethereumVanityCreate2Cmd = &cli.Command{
 		saltPrefix := cmd.String("salt-prefix")
 		contractBin := cmd.String("contract-bin")
 		constructorArgs := cmd.StringSlice("constructor-args")
+		saltScheme, err := create2SaltScheme(cmd)
+		if err != nil {
+			return err
+		}
+
+		return ethereum.VanityCreate2Address(config, deployer, saltPrefix, saltScheme, contractBin, constructorArgs)
+	},
+}
+
+var ethereumVanityCreate3Cmd = &cli.Command{
+	Name:    "vanity-create3",
+	Aliases: []string{"vc3"},
+	Usage:   "Generate vanity CREATE3 address (independent of the contract's init code). Only --salt-scheme createx models a factory's salt handling; the other schemes assume the factory passes the salt to CREATE2 as-is.",
+	Flags:   model.VanityFactoryFlags(),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
+		if err != nil {
+			return err
+		}
+
+		saltScheme, err := create2SaltScheme(cmd)
+		if err != nil {
+			return err
+		}
+
+		return ethereum.VanityCreate3Address(config, cmd.String("deployer"), cmd.String("salt-prefix"), saltScheme)
+	},
+}
+
+// create2SaltScheme reads the --salt-scheme family of flags.
+func create2SaltScheme(cmd *cli.Command) (ethereum.Create2SaltScheme, error) {
+	scheme := ethereum.Create2SaltScheme{
+		Name:    cmd.String("salt-scheme"),
+		ChainID: cmd.Uint64("chain-id"),
+	}
+	if s := cmd.String("salt-address"); s != "" {
+		if !common.IsHexAddress(s) {
+			return scheme, fmt.Errorf("invalid --salt-address: %s", s)
+		}
+		scheme.Address = common.HexToAddress(s)
+	}
+	return scheme, nil
+}
+
+var ethereumCombineCmd = &cli.Command{
+	Name:  "combine",
+	Usage: "Combine your private key with the partial key from a --split-key vanity search.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "partial",
+			Aliases:  []string{"p"},
+			Usage:    "The partial private key (hex) printed by the search.",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "key-file",
+			Usage: "File holding the private key (hex) behind --split-key (default: interactive prompt).",
+		},
+		&cli.StringFlag{
+			Name:  "expect",
+			Usage: "The vanity address the search reported (Ethereum 0x... or Tron T...); fail if the combined key doesn't match.",
+		},
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		secretHex, err := readSplitKeySecret(cmd.String("key-file"))
+		if err != nil {
+			return err
+		}
+		privateKey, err := ethereum.CombineSplitKey(common.FromHex(secretHex), common.FromHex(cmd.String("partial")))
+		if err != nil {
+			return err
+		}
+		pk, err := crypto.ToECDSA(privateKey)
+		if err != nil {
+			return err
+		}
+		address := crypto.PubkeyToAddress(pk.PublicKey)
+		tronAddress := tron.EncodeAddress(address)
 
-		return ethereum.VanityCreate2Address(config, deployer, saltPrefix, contractBin, constructorArgs)
+		if expect := cmd.String("expect"); expect != "" &&
+			!strings.EqualFold(expect, address.Hex()) && expect != tronAddress {
+			return fmt.Errorf("combined key belongs to %s / %s, not %s: wrong private key or partial key", address.Hex(), tronAddress, expect)
+		}
+
+		privateKeyHex := hex.EncodeToString(privateKey)
+		log.Printf("Address: %s", address.Hex())
+		log.Printf("Tron Address: %s", tronAddress)
+		log.Printf("Private Key (hex): %s", privateKeyHex)
+		log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
+		return nil
 	},
 }
 
+// readSplitKeySecret reads the split-key owner's private key from path, or
+// prompts for it without echo so it never lands in shell history.
+func readSplitKeySecret(path string) (string, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read key file: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("private key required: use --key-file")
+	}
+	fmt.Fprint(os.Stderr, "Private key (hex): ")
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	return strings.TrimSpace(string(b)), err
+}
+
 type jsonRpcReq struct {
 	Jsonrpc string      `json:"jsonrpc"`
 	Method  string      `json:"method"`
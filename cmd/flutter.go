@@ -16,7 +16,7 @@ var flutterCmd = &cli.Command{
 	Name:  "flutter",
 	Usage: "Enhanced flutter command.",
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		_, env, err := GetGitSSHCommandEnv(cmd.String("git-user"), cmd.String("proxy"))
+		_, env, err := GetGitSSHCommandEnv(resolveGitUserHere(cmd.String("git-user")), cmd.String("proxy"))
 		if err != nil {
 			return err
 		}
@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/naiba/nb/internal"
+	"github.com/naiba/nb/model"
 )
 
 func init() {
@@ -24,9 +31,12 @@ var gitCmd = &cli.Command{
 		gitSetupCommand,
 		gitSalonCommand,
 		gitCleanHistoryCommand,
+		gitAuditCommand,
+		gitEachCommand,
+		gitWorkspaceCommand,
 	},
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		_, env, err := GetGitSSHCommandEnv(cmd.String("git-user"), cmd.String("proxy"))
+		_, env, err := GetGitSSHCommandEnv(resolveGitUserHere(cmd.String("git-user")), cmd.String("proxy"))
 		if err != nil {
 			return err
 		}
@@ -36,37 +46,197 @@ var gitCmd = &cli.Command{
 
 var gitSetupCommand = &cli.Command{
 	Name:  "setup",
-	Usage: "Setup or tear-down the git account config locally.",
+	Usage: "Setup or tear-down the git account config locally, commit signing included.",
 	Action: func(ctx context.Context, cmd *cli.Command) error {
 		account, env, err := GetGitSSHCommandEnv(cmd.String("git-user"), cmd.String("proxy"))
 		if err != nil {
 			return err
 		}
-		if account == nil {
-			if err := internal.BashScriptExecuteInHost("git config --local --unset core.sshCommand && git config --local --unset user.name && git config --local --unset user.email"); err != nil {
-				return err
+		var mode, sshCommand, allowedSigners string
+		if account != nil {
+			_, sshCommand, _ = strings.Cut(env[0], "=")
+			if mode, err = account.GetSignMode(); err != nil {
+				return fmt.Errorf("git user %s: %w", cmd.String("git-user"), err)
 			}
-		} else {
-			_, command, _ := strings.Cut(env[0], "=")
-			command = "git config --local core.sshCommand '" + command +
-				"' && git config --local user.name " + account.Name +
-				" && git config --local user.email " + account.Email
-			if account.SSHSignKey != "" {
-				command += " && git config --local gpg.format ssh && git config --local user.signingkey " + account.SSHSignKey
+			if mode == model.GitSignModeSSH {
+				if allowedSigners, err = addAllowedSigner(account); err != nil {
+					return err
+				}
 			}
-			if err := internal.BashScriptExecuteInHost(command); err != nil {
-				return err
+		}
+		for _, setting := range gitSetupConfig(account, mode, sshCommand, allowedSigners) {
+			if err := setLocalGitConfig(setting.key, setting.value); err != nil {
+				return fmt.Errorf("failed to set %s: %w", setting.key, err)
 			}
 		}
 		return gitWhoCommand.Action(ctx, cmd)
 	},
 }
 
+type gitConfigSetting struct {
+	key   string
+	value string // "" unsets the key
+}
+
+// gitSetupConfig returns the local settings that make the repository use
+// account, or that drop nb's settings when account is nil. Signing is always
+// spelled out, so the account overrides whatever the global config says.
+func gitSetupConfig(account *model.GitAccount, mode, sshCommand, allowedSigners string) []gitConfigSetting {
+	if account == nil {
+		return []gitConfigSetting{
+			{key: "core.sshCommand"},
+			{key: "user.name"},
+			{key: "user.email"},
+			{key: "gpg.format"},
+			{key: "user.signingkey"},
+			{key: "gpg.ssh.allowedSignersFile"},
+			{key: "commit.gpgsign"},
+			{key: "tag.gpgsign"},
+		}
+	}
+
+	settings := []gitConfigSetting{
+		{"core.sshCommand", sshCommand},
+		{"user.name", account.Name},
+		{"user.email", account.Email},
+	}
+	switch mode {
+	case model.GitSignModeSSH:
+		settings = append(settings,
+			gitConfigSetting{"gpg.format", "ssh"},
+			gitConfigSetting{"user.signingkey", sshSigningKeyConfig(account.SSHSignKey)},
+			gitConfigSetting{"gpg.ssh.allowedSignersFile", allowedSigners},
+		)
+	case model.GitSignModeOpenPGP:
+		settings = append(settings,
+			gitConfigSetting{"gpg.format", "openpgp"},
+			gitConfigSetting{"user.signingkey", account.GPGSignKey},
+			gitConfigSetting{key: "gpg.ssh.allowedSignersFile"},
+		)
+	default:
+		settings = append(settings,
+			gitConfigSetting{key: "gpg.format"},
+			gitConfigSetting{key: "user.signingkey"},
+			gitConfigSetting{key: "gpg.ssh.allowedSignersFile"},
+		)
+	}
+	commits, tags := account.AutoSign()
+	return append(settings,
+		gitConfigSetting{"commit.gpgsign", strconv.FormatBool(commits)},
+		gitConfigSetting{"tag.gpgsign", strconv.FormatBool(tags)},
+	)
+}
+
+// setLocalGitConfig sets or unsets key in the repository config. Unsetting
+// a key that isn't there is not an error.
+func setLocalGitConfig(key, value string) error {
+	if value != "" {
+		return internal.ExecuteInHost(nil, "git", "config", "--local", key, value)
+	}
+	err := internal.ExecuteInHost(nil, "git", "config", "--local", "--unset-all", key)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 5 {
+		return nil
+	}
+	return err
+}
+
+// isSSHPublicKey reports whether s is an OpenSSH public key line rather than
+// a path.
+func isSSHPublicKey(s string) bool {
+	return strings.HasPrefix(s, "ssh-") || strings.HasPrefix(s, "ecdsa-") || strings.HasPrefix(s, "sk-")
+}
+
+// sshSigningKeyConfig turns SSHSignKey into a user.signingkey value: a key
+// literal gets git's key:: prefix, a path has ~ expanded since git won't.
+func sshSigningKeyConfig(signKey string) string {
+	if key, ok := strings.CutPrefix(signKey, "key::"); ok || isSSHPublicKey(key) {
+		return "key::" + key
+	}
+	return expandUserPath(signKey)
+}
+
+// sshSigningPublicKey returns the "<type> <base64>" public key behind
+// SSHSignKey. A private key path is read through its .pub sibling.
+func sshSigningPublicKey(signKey string) (string, error) {
+	key := strings.TrimPrefix(signKey, "key::")
+	if !isSSHPublicKey(key) {
+		path := expandUserPath(key)
+		if !strings.HasSuffix(path, ".pub") {
+			if _, err := os.Stat(path + ".pub"); err == nil {
+				path += ".pub"
+			}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read ssh sign key: %w", err)
+		}
+		key = strings.TrimSpace(string(data))
+		if !isSSHPublicKey(key) {
+			return "", fmt.Errorf("%s is not an ssh public key", path)
+		}
+	}
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("malformed ssh public key %q", key)
+	}
+	return fields[0] + " " + fields[1], nil
+}
+
+// addAllowedSigner records the account's email and ssh signing key in nb's
+// allowed signers file, shared by every account so `git log
+// --show-signature` verifies all of them, and returns the file's path.
+func addAllowedSigner(account *model.GitAccount) (string, error) {
+	publicKey, err := sshSigningPublicKey(account.SSHSignKey)
+	if err != nil {
+		return "", err
+	}
+	path := expandUserPath("~/.config/nb/allowed_signers")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	entry := account.Email + " " + publicKey
+	if hasAllowedSigner(string(existing), entry) {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		entry = "\n" + entry
+	}
+	if _, err := f.WriteString(entry + "\n"); err != nil {
+		return "", err
+	}
+	fmt.Println("Added " + account.Email + " to " + path)
+	return path, nil
+}
+
+// hasAllowedSigner reports whether content already lists the principal with
+// the key, whatever options or comment the line carries.
+func hasAllowedSigner(content, entry string) bool {
+	principal, key, _ := strings.Cut(entry, " ")
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == principal && strings.Contains(strings.Join(fields[1:], " "), key) {
+			return true
+		}
+	}
+	return false
+}
+
 var gitCommitCommand = &cli.Command{
 	Name:            "commit",
 	SkipFlagParsing: true,
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		account, env, err := GetGitSSHCommandEnv(cmd.String("git-user"), cmd.String("proxy"))
+		account, env, err := GetGitSSHCommandEnv(resolveGitUserHere(cmd.String("git-user")), cmd.String("proxy"))
 		if err != nil {
 			return err
 		}
@@ -86,10 +256,6 @@ var gitSalonCommand = &cli.Command{
 	SkipFlagParsing: true,
 	Action: func(ctx context.Context, cmd *cli.Command) error {
 		args := []string{"clone"}
-		_, env, err := GetGitSSHCommandEnv(cmd.String("git-user"), cmd.String("proxy"))
-		if err != nil {
-			return err
-		}
 
 		cmdArgs := cmd.Args().Slice()
 		args = append(args, cmdArgs...)
@@ -113,15 +279,32 @@ var gitSalonCommand = &cli.Command{
 			return fmt.Errorf("missing git repository URL")
 		}
 
+		// Rules see the URL being cloned and the directory it lands in.
+		user := cmd.String("git-user")
+		if user == "" {
+			cwd, _ := os.Getwd()
+			var rule *model.GitRule
+			if user, rule = ResolveGitUser("", nonFlagArgs[0], filepath.Join(cwd, dirName)); rule != nil {
+				fmt.Fprintf(os.Stderr, "nb: using git account %s (%s)\n", user, describeGitRule(rule))
+			}
+		}
+		_, env, err := GetGitSSHCommandEnv(user, cmd.String("proxy"))
+		if err != nil {
+			return err
+		}
+
 		if err := internal.ExecuteInHost(env, "git", args...); err != nil {
 			return err
 		}
+		if user == "" {
+			return nil
+		}
 
 		setupArgs := []string{"cd", dirName, "&&", "nb"}
 		if cmd.String("proxy") != "" {
 			setupArgs = append(setupArgs, "-p "+cmd.String("proxy"))
 		}
-		setupArgs = append(setupArgs, "-gu "+cmd.String("git-user"))
+		setupArgs = append(setupArgs, "-gu "+user)
 		setupArgs = append(setupArgs, "git setup")
 
 		return internal.BashScriptExecuteInHost(strings.Join(setupArgs, " "))
@@ -131,13 +314,23 @@ var gitSalonCommand = &cli.Command{
 var gitWhoCommand = &cli.Command{
 	Name: "whoami",
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		return internal.BashScriptExecuteInHost("git config --local --list|grep \"user.email\\|user.name\\|core.sshcommand\\|gpg.format\\|user.signingkey\"")
+		if user := cmd.String("git-user"); user != "" {
+			fmt.Println("nb account: " + user + " (-gu)")
+		} else {
+			dir, _ := os.Getwd()
+			if account, rule := ResolveGitUser("", currentGitRemote(), dir); rule != nil {
+				fmt.Println("nb account: " + account + " (" + describeGitRule(rule) + ")")
+			} else {
+				fmt.Println("nb account: none (no -gu, no matching git rule)")
+			}
+		}
+		return internal.BashScriptExecuteInHost("git config --local --list|grep \"user.email\\|user.name\\|core.sshcommand\\|user.signingkey\\|gpg\"")
 	},
 }
 
 var gitCleanHistoryCommand = &cli.Command{
 	Name:  "clean-history",
-	Usage: "Clean all commit history, keeping only current files.",
+	Usage: "Clean commit history, keeping only current files; the old history stays under refs/nb-backup.",
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:    "message",
@@ -145,6 +338,14 @@ var gitCleanHistoryCommand = &cli.Command{
 			Value:   "Initial commit",
 			Usage:   "Commit message for the new initial commit",
 		},
+		&cli.IntFlag{
+			Name:  "keep-last",
+			Usage: "Keep the last N commits on top of the new initial commit, squashing only older history; they must not include merges",
+		},
+		&cli.BoolFlag{
+			Name:  "push",
+			Usage: "Force-push the branch with lease, using the git account's SSH command",
+		},
 		&cli.BoolFlag{
 			Name:    "force",
 			Aliases: []string{"f"},
@@ -152,8 +353,31 @@ var gitCleanHistoryCommand = &cli.Command{
 		},
 	},
 	Action: func(ctx context.Context, cmd *cli.Command) error {
+		keepLast := cmd.Int("keep-last")
+		if keepLast < 0 {
+			return errors.New("--keep-last must not be negative")
+		}
+		branch, err := gitOutput(nil, "symbolic-ref", "--short", "HEAD")
+		if err != nil {
+			return fmt.Errorf("failed to get current branch: %w", err)
+		}
+		old, err := gitOutput(nil, "rev-parse", "HEAD")
+		if err != nil {
+			return err
+		}
+		var keep []string
+		if keepLast > 0 {
+			if keep, err = gitKeepLast(branch, keepLast); err != nil {
+				return err
+			}
+		}
+
 		if !cmd.Bool("force") {
-			fmt.Print("WARNING: This will permanently delete all commit history. Continue? [y/N]: ")
+			if keepLast > 0 {
+				fmt.Printf("WARNING: This will squash all but the last %d commits of %s into one. Continue? [y/N]: ", keepLast, branch)
+			} else {
+				fmt.Print("WARNING: This will replace all commit history of " + branch + " with one commit. Continue? [y/N]: ")
+			}
 			var confirm string
 			fmt.Scanln(&confirm)
 			if strings.ToLower(confirm) != "y" {
@@ -162,28 +386,107 @@ var gitCleanHistoryCommand = &cli.Command{
 			}
 		}
 
-		// Get current branch name
-		branchBytes, err := internal.ExecuteInHostWithOutput(nil, "git", "rev-parse", "--abbrev-ref", "HEAD")
+		backup, err := cleanGitHistory(branch, old, cmd.String("message"), keep)
 		if err != nil {
-			return fmt.Errorf("failed to get current branch: %w", err)
+			return err
 		}
-		branch := strings.TrimSpace(string(branchBytes))
-
-		message := cmd.String("message")
-		script := fmt.Sprintf(`
-git checkout --orphan temp_clean_history_branch && \
-git add -A && \
-git commit -m "%s" && \
-git branch -D %s && \
-git branch -m %s
-`, message, branch, branch)
 
-		if err := internal.BashScriptExecuteInHost(script); err != nil {
-			return fmt.Errorf("failed to clean history: %w", err)
+		fmt.Println("Successfully cleaned commit history.")
+		fmt.Println("Backup: " + backup + " (restore: git reset --hard " + backup + ")")
+		remote := gitConfigValue("branch." + branch + ".remote")
+		if remote == "" {
+			remote = "origin"
+		}
+		if !cmd.Bool("push") {
+			fmt.Println("To push changes, run: nb git push --force-with-lease " + remote + " " + branch)
+			return nil
 		}
 
-		fmt.Println("Successfully cleaned commit history.")
-		fmt.Println("To push changes, run: git push -f origin " + branch)
-		return nil
+		_, env, err := GetGitSSHCommandEnv(resolveGitUserHere(cmd.String("git-user")), cmd.String("proxy"))
+		if err != nil {
+			return err
+		}
+		return internal.ExecuteInHost(env, "git", "push", "--force-with-lease", remote, branch)
 	},
 }
+
+// gitKeepLast returns the newest commit --keep-last squashes followed by the
+// keepLast first-parent commits it keeps, oldest first. Merges among the kept
+// commits are refused: replaying them would flatten them.
+func gitKeepLast(branch string, keepLast int) ([]string, error) {
+	out, err := gitOutput(nil, "rev-list", "--first-parent", "--reverse", "-n", strconv.Itoa(keepLast+1), "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	keep := strings.Fields(out)
+	if len(keep) <= keepLast {
+		return nil, fmt.Errorf("%s has only %d commits, nothing older than the last %d to squash", branch, len(keep), keepLast)
+	}
+	merge, err := gitOutput(nil, "rev-list", "--first-parent", "--merges", "-n", "1", keep[0]+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if merge != "" {
+		return nil, fmt.Errorf("the last %d commits of %s include merge %s, which --keep-last can't keep; keep fewer or none", keepLast, branch, merge[:12])
+	}
+	return keep, nil
+}
+
+// cleanGitHistory backs up branch, currently at old, then points it at a
+// single commit with message and, with keep from gitKeepLast, the kept
+// commits replayed on top. Without keep the new commit has the working
+// tree's files. It returns the backup ref.
+func cleanGitHistory(branch, old, message string, keep []string) (string, error) {
+	backup := "refs/nb-backup/" + branch + "/" + time.Now().Format("20060102-150405")
+	if _, err := gitOutput(nil, "update-ref", backup, old, ""); err != nil {
+		return "", fmt.Errorf("failed to create backup ref: %w", err)
+	}
+
+	var head string
+	var err error
+	if len(keep) > 0 {
+		// keep[0] is the newest squashed commit; its tree becomes the
+		// new root and the kept commits are replayed on top as-is.
+		if head, err = gitOutput(nil, "commit-tree", keep[0]+"^{tree}", "-m", message); err != nil {
+			return "", err
+		}
+		for _, commit := range keep[1:] {
+			if head, err = replayCommit(commit, head); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		if _, err := gitOutput(nil, "add", "-A"); err != nil {
+			return "", err
+		}
+		tree, err := gitOutput(nil, "write-tree")
+		if err != nil {
+			return "", err
+		}
+		if head, err = gitOutput(nil, "commit-tree", tree, "-m", message); err != nil {
+			return "", err
+		}
+	}
+	if _, err := gitOutput(nil, "update-ref", "-m", "nb git clean-history", "refs/heads/"+branch, head, old); err != nil {
+		return "", fmt.Errorf("failed to move %s: %w", branch, err)
+	}
+	return backup, nil
+}
+
+// replayCommit recreates commit on top of parent with the same tree, message
+// and author, and returns the new commit.
+func replayCommit(commit, parent string) (string, error) {
+	out, err := gitOutput(nil, "show", "-s", "--format=%an%x00%ae%x00%ad%x00%B", "--date=raw", commit)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.SplitN(out, "\x00", 4)
+	if len(fields) != 4 {
+		return "", fmt.Errorf("failed to read commit %s", commit)
+	}
+	return gitOutput([]string{
+		"GIT_AUTHOR_NAME=" + fields[0],
+		"GIT_AUTHOR_EMAIL=" + fields[1],
+		"GIT_AUTHOR_DATE=" + fields[2],
+	}, "commit-tree", commit+"^{tree}", "-p", parent, "-m", fields[3])
+}
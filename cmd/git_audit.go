@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal"
+	"github.com/naiba/nb/model"
+)
+
+// unpushedRevs selects the commits on HEAD that no remote branch has.
+var unpushedRevs = []string{"HEAD", "--not", "--remotes"}
+
+var gitAuditCommand = &cli.Command{
+	Name:      "audit",
+	Usage:     "Flag commits whose author, committer or signature doesn't match the repository's git account.",
+	ArgsUsage: "[range] (default: unpushed commits)",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "fix",
+			Usage: "Rewrite the author and committer of the unpushed commits to the expected account",
+		},
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		identity, err := expectedGitIdentity(cmd.String("git-user"))
+		if err != nil {
+			return err
+		}
+		fmt.Println("Expected: " + identity.String())
+
+		revs := cmd.Args().Slice()
+		if len(revs) == 0 {
+			revs = unpushedRevs
+		} else if cmd.Bool("fix") {
+			return errors.New("--fix only rewrites unpushed commits, drop the range")
+		}
+		commits, err := gitAuditLog(revs...)
+		if err != nil {
+			return err
+		}
+
+		var flagged []auditCommit
+		for _, commit := range commits {
+			problems := commit.problems(identity)
+			if len(problems) == 0 {
+				continue
+			}
+			flagged = append(flagged, commit)
+			fmt.Println(commit.hash[:12] + " " + commit.subject)
+			for _, problem := range problems {
+				fmt.Println("    " + problem)
+			}
+		}
+		if len(flagged) == 0 {
+			fmt.Printf("%d commits checked, all match.\n", len(commits))
+			return nil
+		}
+
+		if cmd.Bool("fix") {
+			oldest := flagged[len(flagged)-1].hash
+			if err := fixGitAuthors(identity, oldest); err != nil {
+				return fmt.Errorf("failed to rewrite commits: %w", err)
+			}
+			fmt.Printf("Rewrote the commits from %s on as %s <%s>.\n", oldest[:12], identity.name, identity.email)
+			if identity.sign && gitConfigValue("commit.gpgsign") != "true" {
+				fmt.Println("Signing isn't set up here, so they are still unsigned: run `nb" + identity.flag() + " git setup` and --fix again.")
+			}
+			return nil
+		}
+
+		if len(cmd.Args().Slice()) == 0 {
+			fmt.Println("Fix: nb" + identity.flag() + " git audit --fix")
+		} else {
+			fmt.Println("Fix: nb" + identity.flag() + " git audit --fix rewrites the unpushed ones; pushed commits need a history rewrite and a force-push.")
+		}
+		return fmt.Errorf("%d of %d commits need attention", len(flagged), len(commits))
+	},
+}
+
+// gitIdentity is who commits in the repository should come from.
+type gitIdentity struct {
+	account string // nb account, "" when only the local git config names one
+	source  string // what picked it: -gu, nb git setup or a rule
+	name    string
+	email   string
+	sign    bool
+}
+
+func (id *gitIdentity) String() string {
+	s := id.name + " <" + id.email + ">"
+	if id.account != "" {
+		s += ", account " + id.account
+	}
+	s += " (" + id.source + ")"
+	if id.sign {
+		s += ", signed"
+	}
+	return s
+}
+
+// flag is the -gu argument that reproduces the identity, if one is needed.
+func (id *gitIdentity) flag() string {
+	if id.account == "" || id.source != "-gu" {
+		return ""
+	}
+	return " -gu " + id.account
+}
+
+func newGitIdentity(user string, account *model.GitAccount, source string) *gitIdentity {
+	sign, _ := account.AutoSign()
+	return &gitIdentity{account: user, source: source, name: account.Name, email: account.Email, sign: sign}
+}
+
+// expectedGitIdentity picks the identity to audit against: -gu, then the
+// account `nb git setup` wrote into the local config, then the git rules.
+func expectedGitIdentity(user string) (*gitIdentity, error) {
+	if user != "" {
+		account, _, err := GetGitSSHCommandEnv(user, "")
+		if err != nil {
+			return nil, err
+		}
+		return newGitIdentity(user, account, "-gu"), nil
+	}
+
+	if email := gitConfigValue("user.email"); email != "" {
+		if user := GitUserByEmail(email); user != "" {
+			account, _, err := GetGitSSHCommandEnv(user, "")
+			if err != nil {
+				return nil, err
+			}
+			return newGitIdentity(user, account, "nb git setup"), nil
+		}
+		return &gitIdentity{
+			source: "local git config",
+			name:   gitConfigValue("user.name"),
+			email:  email,
+			sign:   gitConfigValue("commit.gpgsign") == "true",
+		}, nil
+	}
+
+	dir, _ := os.Getwd()
+	if user, rule := ResolveGitUser("", currentGitRemote(), dir); rule != nil {
+		account, _, err := GetGitSSHCommandEnv(user, "")
+		if err != nil {
+			return nil, err
+		}
+		return newGitIdentity(user, account, describeGitRule(rule)), nil
+	}
+	return nil, errors.New("no expected identity: pass -gu, run nb git setup or add a git rule")
+}
+
+// gitConfigValue returns a key from the repository's local config, or "".
+func gitConfigValue(key string) string {
+	value, _ := gitOutput(nil, "config", "--local", "--get", key)
+	return value
+}
+
+type auditCommit struct {
+	hash           string
+	authorName     string
+	authorEmail    string
+	committerName  string
+	committerEmail string
+	signature      string // git's %G?: N unsigned, B bad, G/U/X/Y/R/E signed
+	subject        string
+}
+
+// gitAuditFormat separates fields with US and records with RS, which
+// neither names nor subjects contain.
+const gitAuditFormat = "--format=%H%x1f%an%x1f%ae%x1f%cn%x1f%ce%x1f%G?%x1f%s%x1e"
+
+// gitAuditLog returns the commits selected by revs, children before
+// parents.
+func gitAuditLog(revs ...string) ([]auditCommit, error) {
+	out, err := gitOutput(nil, append([]string{"log", "--topo-order", gitAuditFormat}, revs...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitAuditLog(out), nil
+}
+
+func parseGitAuditLog(out string) []auditCommit {
+	var commits []auditCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 7 {
+			continue
+		}
+		commits = append(commits, auditCommit{
+			hash:           fields[0],
+			authorName:     fields[1],
+			authorEmail:    fields[2],
+			committerName:  fields[3],
+			committerEmail: fields[4],
+			signature:      fields[5],
+			subject:        fields[6],
+		})
+	}
+	return commits
+}
+
+// problems lists how the commit differs from want.
+func (c auditCommit) problems(want *gitIdentity) []string {
+	var problems []string
+	if !strings.EqualFold(c.authorEmail, want.email) {
+		problems = append(problems, fmt.Sprintf("author %s <%s>, want <%s>", c.authorName, c.authorEmail, want.email))
+	}
+	if !strings.EqualFold(c.committerEmail, want.email) {
+		problems = append(problems, fmt.Sprintf("committer %s <%s>, want <%s>", c.committerName, c.committerEmail, want.email))
+	}
+	if want.sign {
+		switch c.signature {
+		case "N":
+			problems = append(problems, "unsigned")
+		case "B":
+			problems = append(problems, "bad signature")
+		}
+	}
+	return problems
+}
+
+// fixGitAuthorsExec amends the commit the rebase just made unless a remote
+// branch has it. Pushed commits, such as teammates' work merged in after
+// oldest, keep their parents through the rebase and so their hashes, which
+// is what the check sees.
+const fixGitAuthorsExec = `git for-each-ref --contains HEAD --count=1 --format=x refs/remotes | grep -q . || ` +
+	`git commit --amend --no-edit --allow-empty --author="$NB_AUDIT_AUTHOR"`
+
+// fixGitAuthors re-commits the unpushed commits from oldest on as identity,
+// keeping messages and author dates. New commits are signed if the
+// repository signs by default.
+func fixGitAuthors(identity *gitIdentity, oldest string) error {
+	args := []string{"rebase", "--rebase-merges", "--exec", fixGitAuthorsExec}
+	if parent, err := gitOutput(nil, "rev-parse", "--verify", "--quiet", oldest+"^"); err == nil {
+		args = append(args, parent)
+	} else {
+		args = append(args, "--root")
+	}
+	return internal.ExecuteInHost([]string{
+		"NB_AUDIT_AUTHOR=" + identity.name + " <" + identity.email + ">",
+		"GIT_COMMITTER_NAME=" + identity.name,
+		"GIT_COMMITTER_EMAIL=" + identity.email,
+	}, "git", args...)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseGitAuditLog(t *testing.T) {
+	out := "aaaa\x1fJane\x1fjane@example.com\x1fJane\x1fjane@example.com\x1fG\x1ffix: a, b\x1e\n" +
+		"bbbb\x1fBot\x1fbot@example.com\x1fJane\x1fjane@example.com\x1fN\x1f\x1e\n"
+	got := parseGitAuditLog(out)
+	want := []auditCommit{
+		{"aaaa", "Jane", "jane@example.com", "Jane", "jane@example.com", "G", "fix: a, b"},
+		{"bbbb", "Bot", "bot@example.com", "Jane", "jane@example.com", "N", ""},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAuditCommit_Problems(t *testing.T) {
+	signed := &gitIdentity{name: "Jane", email: "Jane@Example.com", sign: true}
+	unsigned := &gitIdentity{name: "Jane", email: "jane@example.com"}
+	tests := []struct {
+		name   string
+		commit auditCommit
+		want   *gitIdentity
+		count  int
+	}{
+		{"all good", auditCommit{authorEmail: "jane@example.com", committerEmail: "jane@example.com", signature: "G"}, signed, 0},
+		{"unsigned", auditCommit{authorEmail: "jane@example.com", committerEmail: "jane@example.com", signature: "N"}, signed, 1},
+		{"unsigned not expected", auditCommit{authorEmail: "jane@example.com", committerEmail: "jane@example.com", signature: "N"}, unsigned, 0},
+		{"wrong author", auditCommit{authorEmail: "me@home.net", committerEmail: "jane@example.com", signature: "N"}, unsigned, 1},
+		{"wrong everything", auditCommit{authorEmail: "me@home.net", committerEmail: "me@home.net", signature: "B"}, signed, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.commit.problems(tt.want); len(got) != tt.count {
+				t.Errorf("got %q, want %d problems", got, tt.count)
+			}
+		})
+	}
+}
+
+// newTestGitRepo makes the working directory an empty repository, isolated
+// from the user's git config, and returns a helper that runs git in it.
+func newTestGitRepo(t *testing.T) func(env []string, args ...string) string {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	git := func(env []string, args ...string) string {
+		t.Helper()
+		out, err := gitOutput(env, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	git(nil, "init", "--quiet", "--initial-branch=main")
+	git(nil, "config", "user.name", "nb")
+	git(nil, "config", "user.email", "nb@example.com")
+	return git
+}
+
+// gitAs is the environment for committing as email.
+func gitAs(email string) []string {
+	name, _, _ := strings.Cut(email, "@")
+	return []string{"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email}
+}
+
+func TestFixGitAuthors_KeepsPushedCommits(t *testing.T) {
+	git := newTestGitRepo(t)
+	me, teammate := gitAs("me@home.net"), gitAs("bob@example.com")
+	git(me, "commit", "--quiet", "--allow-empty", "-m", "base")
+	git(nil, "update-ref", "refs/remotes/origin/main", "HEAD")
+	git(nil, "checkout", "--quiet", "-b", "feature")
+	git(teammate, "commit", "--quiet", "--allow-empty", "-m", "teammate")
+	pushed := git(nil, "rev-parse", "HEAD")
+	git(nil, "update-ref", "refs/remotes/origin/feature", "HEAD")
+	git(nil, "checkout", "--quiet", "main")
+	git(me, "commit", "--quiet", "--allow-empty", "-m", "mine 1")
+	git(me, "merge", "--quiet", "--no-ff", "-m", "merge feature", "origin/feature")
+	git(me, "commit", "--quiet", "--allow-empty", "-m", "mine 2")
+
+	commits, err := gitAuditLog(unpushedRevs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("%d unpushed commits, want 3", len(commits))
+	}
+	identity := &gitIdentity{name: "Jane", email: "jane@example.com"}
+	if err := fixGitAuthors(identity, commits[len(commits)-1].hash); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := git(nil, "log", "--format=%ae %ce %s", "--first-parent", "origin/main..HEAD"); got !=
+		"jane@example.com jane@example.com mine 2\n"+
+			"jane@example.com jane@example.com merge feature\n"+
+			"jane@example.com jane@example.com mine 1" {
+		t.Errorf("rewritten commits:\n%s", got)
+	}
+	if got := git(nil, "rev-parse", "HEAD^^2"); got != pushed {
+		t.Errorf("merged teammate commit is now %s, want %s", got, pushed)
+	}
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal"
+)
+
+// eachStopOnArg stops flag parsing at the git subcommand, so its flags reach
+// git untouched.
+var eachStopOnArg = 1
+
+func workspaceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "root",
+			Value: ".",
+			Usage: "Directory to discover git repositories under",
+		},
+		&cli.IntFlag{
+			Name:  "depth",
+			Value: 3,
+			Usage: "How many directory levels below the root to look for repositories",
+		},
+		&cli.IntFlag{
+			Name:    "jobs",
+			Aliases: []string{"j"},
+			Value:   8,
+			Usage:   "How many repositories to work on at once",
+		},
+	}
+}
+
+var gitEachCommand = &cli.Command{
+	Name:         "each",
+	Usage:        "Run a git command in every repository under a directory, each with its own account and the proxy.",
+	ArgsUsage:    "<git command> [args...]",
+	StopOnNthArg: &eachStopOnArg,
+	Flags:        workspaceFlags(),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		args := cmd.Args().Slice()
+		if len(args) == 0 {
+			return errors.New("missing git command, e.g. nb git each fetch --prune")
+		}
+		repos, err := workspaceRepos(cmd)
+		if err != nil {
+			return err
+		}
+
+		var failed []string
+		runWorkspace(repos, cmd.Int("jobs"), func(repo *workspaceRepo) (string, error) {
+			out, err := internal.ExecuteArgsInHostWithCombinedOutput(repo.env, "git", append([]string{"-C", repo.dir}, args...)...)
+			return string(out), err
+		}, func(repo *workspaceRepo, out string, err error) {
+			fmt.Println("==> " + repo.String())
+			if out != "" {
+				fmt.Print(out)
+				if !strings.HasSuffix(out, "\n") {
+					fmt.Println()
+				}
+			}
+			if err != nil {
+				failed = append(failed, repo.rel)
+				fmt.Println("failed: " + err.Error())
+			}
+		})
+
+		fmt.Printf("%d repositories, %d failed\n", len(repos), len(failed))
+		if len(failed) > 0 {
+			return fmt.Errorf("git %s failed in: %s", args[0], strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+var gitWorkspaceCommand = &cli.Command{
+	Name:  "workspace",
+	Usage: "Work with every git repository under a directory.",
+	Commands: []*cli.Command{
+		gitWorkspaceStatusCommand,
+	},
+}
+
+var gitWorkspaceStatusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "Show branch, dirty files and ahead/behind of every repository.",
+	Flags: append(workspaceFlags(), &cli.BoolFlag{
+		Name:  "fetch",
+		Usage: "Fetch every repository first, so ahead/behind is current",
+	}),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		repos, err := workspaceRepos(cmd)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "REPO\tBRANCH\tDIRTY\tAHEAD\tBEHIND\tACCOUNT")
+		var summary workspaceSummary
+		var failures []string
+		runWorkspace(repos, cmd.Int("jobs"), func(repo *workspaceRepo) (string, error) {
+			if cmd.Bool("fetch") {
+				if out, err := internal.ExecuteArgsInHostWithCombinedOutput(repo.env, "git", "-C", repo.dir, "fetch", "--quiet"); err != nil {
+					return "", fmt.Errorf("fetch: %s", strings.TrimSpace(string(out)))
+				}
+			}
+			out, err := internal.ExecuteArgsInHostWithOutput(nil, "git", "-C", repo.dir, "status", "--porcelain=v2", "--branch")
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return "", fmt.Errorf("status: %s", strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return string(out), err
+		}, func(repo *workspaceRepo, out string, err error) {
+			account := repo.account
+			if account == "" {
+				account = "-"
+			}
+			if err != nil {
+				summary.failed++
+				failures = append(failures, repo.rel+": "+err.Error())
+				fmt.Fprintf(w, "%s\t(error)\t\t\t\t%s\n", repo.rel, account)
+				return
+			}
+			status := parseWorkspaceStatus(out)
+			summary.add(status)
+			ahead, behind := "-", "-"
+			if status.upstream {
+				ahead, behind = strconv.Itoa(status.ahead), strconv.Itoa(status.behind)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", repo.rel, status.branch, status.dirty, ahead, behind, account)
+		})
+		w.Flush()
+
+		for _, failure := range failures {
+			fmt.Println(failure)
+		}
+		fmt.Printf("%d repositories: %s\n", len(repos), summary)
+		if summary.failed > 0 {
+			return fmt.Errorf("status failed in %d repositories", summary.failed)
+		}
+		return nil
+	},
+}
+
+// workspaceRepo is a discovered repository and how to run git in it.
+type workspaceRepo struct {
+	dir     string
+	rel     string // dir relative to the root, for display
+	account string // nb account in use, "" for the repository's own config
+	env     []string
+}
+
+func (r *workspaceRepo) String() string {
+	if r.account == "" {
+		return r.rel
+	}
+	return r.rel + " (" + r.account + ")"
+}
+
+// workspaceRepos discovers the repositories under --root and resolves each
+// one's account the way commands inside it would: -gu, then the account
+// `nb git setup` wrote to it, then the git rules. The proxy applies to all.
+func workspaceRepos(cmd *cli.Command) ([]*workspaceRepo, error) {
+	root, err := filepath.Abs(cmd.String("root"))
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := discoverGitRepos(root, cmd.Int("depth"))
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no git repositories under %s", root)
+	}
+	proxyEnv, err := GetProxyEnv(cmd.String("proxy"))
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]*workspaceRepo, 0, len(dirs))
+	for _, dir := range dirs {
+		repo := &workspaceRepo{dir: dir, account: cmd.String("git-user")}
+		if repo.rel, err = filepath.Rel(root, dir); err != nil {
+			return nil, err
+		}
+		if repo.account == "" {
+			email, _ := gitOutput(nil, "-C", dir, "config", "--local", "--get", "user.email")
+			repo.account = GitUserByEmail(email)
+		}
+		if repo.account == "" {
+			remote, _ := gitOutput(nil, "-C", dir, "config", "--get", "remote.origin.url")
+			repo.account, _ = ResolveGitUser("", remote, dir)
+		}
+		// Without an account, the repository's core.sshCommand, if any, is
+		// left to apply.
+		_, sshEnv, err := GetGitSSHCommandEnv(repo.account, cmd.String("proxy"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo.rel, err)
+		}
+		repo.env = append(append(sshEnv, proxyEnv...), "GIT_TERMINAL_PROMPT=0")
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+// discoverGitRepos returns the repositories at or below root, at most depth
+// levels down, in path order. It doesn't look inside repositories or hidden
+// directories.
+func discoverGitRepos(root string, depth int) ([]string, error) {
+	var repos []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // unreadable directories are skipped
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		// .git is a directory in a clone and a file in worktrees and
+		// submodules.
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+		if rel, _ := filepath.Rel(root, path); rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= depth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return repos, err
+}
+
+// runWorkspace runs work on up to jobs repositories at once and hands each
+// result to report in repository order, as soon as it and those before it
+// are done.
+func runWorkspace(repos []*workspaceRepo, jobs int, work func(*workspaceRepo) (string, error), report func(*workspaceRepo, string, error)) {
+	type result struct {
+		out string
+		err error
+	}
+	results := make([]chan result, len(repos))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+	next := make(chan int)
+	for range min(max(jobs, 1), len(repos)) {
+		go func() {
+			for i := range next {
+				out, err := work(repos[i])
+				results[i] <- result{out, err}
+			}
+		}()
+	}
+	go func() {
+		for i := range repos {
+			next <- i
+		}
+		close(next)
+	}()
+
+	for i, repo := range repos {
+		r := <-results[i]
+		report(repo, r.out, r.err)
+	}
+}
+
+type workspaceStatus struct {
+	branch   string
+	dirty    int // changed, untracked and conflicted paths
+	upstream bool
+	ahead    int
+	behind   int
+}
+
+// parseWorkspaceStatus reads `git status --porcelain=v2 --branch`.
+func parseWorkspaceStatus(out string) workspaceStatus {
+	var status workspaceStatus
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "# branch.head "):
+			status.branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			status.upstream = true
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &status.ahead, &status.behind)
+		case strings.HasPrefix(line, "#"):
+		default:
+			status.dirty++
+		}
+	}
+	return status
+}
+
+type workspaceSummary struct {
+	dirty, ahead, behind, failed int
+}
+
+func (s *workspaceSummary) add(status workspaceStatus) {
+	if status.dirty > 0 {
+		s.dirty++
+	}
+	if status.ahead > 0 {
+		s.ahead++
+	}
+	if status.behind > 0 {
+		s.behind++
+	}
+}
+
+func (s workspaceSummary) String() string {
+	text := fmt.Sprintf("%d dirty, %d ahead, %d behind", s.dirty, s.ahead, s.behind)
+	if s.failed > 0 {
+		text += fmt.Sprintf(", %d failed", s.failed)
+	}
+	return text
+}
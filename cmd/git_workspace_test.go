@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiscoverGitRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"a/.git",
+		"a/nested/.git", // inside a repository: not searched
+		"b/c/.git",
+		"b/d",
+		".hidden/e/.git",
+		"f/g/h/i/.git", // deeper than the limit
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A worktree's .git is a file.
+	if err := os.MkdirAll(filepath.Join(root, "wt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "wt", ".git"), []byte("gitdir: ../a/.git/worktrees/wt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := discoverGitRepos(root, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "a"), filepath.Join(root, "b", "c"), filepath.Join(root, "wt")}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, _ := discoverGitRepos(filepath.Join(root, "a"), 3); !slices.Equal(got, []string{filepath.Join(root, "a")}) {
+		t.Errorf("root repository: got %v", got)
+	}
+}
+
+func TestParseWorkspaceStatus(t *testing.T) {
+	out := "# branch.oid 1234\n# branch.head main\n# branch.upstream origin/main\n# branch.ab +2 -1\n" +
+		"1 .M N... 100644 100644 100644 aaaa bbbb f.go\n? new.txt\n"
+	got := parseWorkspaceStatus(out)
+	want := workspaceStatus{branch: "main", dirty: 2, upstream: true, ahead: 2, behind: 1}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got = parseWorkspaceStatus("# branch.oid (initial)\n# branch.head (detached)\n")
+	want = workspaceStatus{branch: "(detached)"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRunWorkspace(t *testing.T) {
+	repos := make([]*workspaceRepo, 10)
+	for i := range repos {
+		repos[i] = &workspaceRepo{rel: string(rune('a' + i))}
+	}
+	var running, peak atomic.Int32
+	var order []string
+	runWorkspace(repos, 3, func(repo *workspaceRepo) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Later repositories finish first; reports must stay in order.
+		time.Sleep(time.Duration('j'-repo.rel[0]+1) * time.Millisecond)
+		if repo.rel == "c" {
+			return "", errors.New("boom")
+		}
+		return repo.rel, nil
+	}, func(repo *workspaceRepo, out string, err error) {
+		if (err != nil) != (repo.rel == "c") || (err == nil && out != repo.rel) {
+			t.Errorf("%s: got (%q, %v)", repo.rel, out, err)
+		}
+		order = append(order, repo.rel)
+	})
+
+	if want := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}; !slices.Equal(order, want) {
+		t.Errorf("reported %v, want %v", order, want)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("%d jobs ran at once, want at most 3", peak.Load())
+	}
+}
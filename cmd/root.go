@@ -60,19 +60,10 @@ var rootCmd = &cli.Command{
 			return nil
 		}
 
-		var env []string
-
-		proxyConfig, err := GetProxyConfig(cmd.String("proxy"))
+		env, err := GetProxyEnv(cmd.String("proxy"))
 		if err != nil {
 			return err
 		}
-		if proxyConfig != nil {
-			env = append(env, fmt.Sprintf("all_proxy=socks5h://%s:%s", proxyConfig.SocksHost, proxyConfig.SocksPort))
-			if proxyConfig.HttpHost != "" {
-				env = append(env, fmt.Sprintf("http_proxy=http://%s:%s", proxyConfig.HttpHost, proxyConfig.HttpPort))
-				env = append(env, fmt.Sprintf("https_proxy=http://%s:%s", proxyConfig.HttpHost, proxyConfig.HttpPort))
-			}
-		}
 
 		if len(args) > 1 {
 			return internal.ExecuteInHost(env, args[0], args[1:]...)
@@ -17,6 +17,7 @@ var solanaCmd = &cli.Command{
 	Usage: "Solana helper.",
 	Commands: []*cli.Command{
 		solanaVanityCmd,
+		solanaVanityPDACmd,
 		decodeTransactionCmd,
 		getTransactionCmd,
 	},
@@ -143,17 +144,71 @@ var decodeTransactionCmd = &cli.Command{
 var solanaVanityCmd = &cli.Command{
 	Name:  "vanity",
 	Usage: "Generate vanity address.",
-	Flags: model.VanityFlags(),
+	Flags: append(append(model.VanityFlags(), model.VanityMnemonicFlags()...),
+		&cli.StringFlag{
+			Name:  "base",
+			Usage: "Grind a CreateAccountWithSeed seed for this base pubkey instead of a keypair; the base key signs the account creation.",
+		},
+		&cli.StringFlag{
+			Name:  "owner",
+			Usage: "With --base: the program that will own the account.",
+			Value: solana.SystemProgramID.String(),
+		},
+		&cli.StringFlag{
+			Name:    "seed-prefix",
+			Aliases: []string{"sp"},
+			Usage:   "With --base: the seed is this prefix followed by a search suffix (0-9a-zA-Z).",
+		},
+	),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		config, err := model.ParseVanityConfig(cmd)
+		config, err := model.ParseVanityConfig(cmd, model.Base58Alphabet)
 		if err != nil {
 			return err
 		}
 
+		if cmd.Bool("mnemonic") {
+			if cmd.String("base") != "" {
+				return errors.New("--mnemonic and --base are mutually exclusive")
+			}
+			return solanax.VanityMnemonicAddress(config, cmd.Int("words"))
+		}
+		if base := cmd.String("base"); base != "" {
+			return solanax.VanityWithSeedAddress(config, base, cmd.String("owner"), cmd.String("seed-prefix"))
+		}
 		return solanax.VanityAddress(config)
 	},
 }
 
+var solanaVanityPDACmd = &cli.Command{
+	Name:    "vanity-pda",
+	Aliases: []string{"vp"},
+	Usage:   "Grind program-derived address seeds for a vanity PDA.",
+	Flags: append(model.VanityFlags(),
+		&cli.StringFlag{
+			Name:     "program",
+			Usage:    "The program id (base58) the PDA belongs to.",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "seed",
+			Usage: "A fixed seed (UTF-8) placed before the ground seed; repeat for several.",
+		},
+		&cli.StringFlag{
+			Name:    "seed-prefix",
+			Aliases: []string{"sp"},
+			Usage:   "The ground seed is this prefix followed by a search suffix (0-9a-zA-Z).",
+		},
+	),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, model.Base58Alphabet)
+		if err != nil {
+			return err
+		}
+
+		return solanax.VanityPDA(config, cmd.String("program"), cmd.StringSlice("seed"), cmd.String("seed-prefix"))
+	},
+}
+
 func init() {
 	rootCmd.Commands = append(rootCmd.Commands, solanaCmd)
 }
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/internal/sui"
+	"github.com/naiba/nb/model"
+)
+
+var suiCmd = &cli.Command{
+	Name:  "sui",
+	Usage: "Sui helper.",
+	Commands: []*cli.Command{
+		suiVanityCmd,
+	},
+}
+
+var suiVanityCmd = &cli.Command{
+	Name:    "vanity",
+	Aliases: []string{"v"},
+	Usage:   "Generate a vanity Sui address (--keystore writes plaintext .key files)",
+	Flags:   model.VanityFlags(),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, model.HexAlphabet)
+		if err != nil {
+			return err
+		}
+		return sui.VanityAddress(config)
+	},
+}
+
+func init() {
+	rootCmd.Commands = append(rootCmd.Commands, suiCmd)
+}
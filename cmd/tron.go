@@ -5,6 +5,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/naiba/nb/internal/ethereum"
 	"github.com/naiba/nb/internal/tron"
 	"github.com/naiba/nb/model"
 )
@@ -14,6 +15,7 @@ var tronCmd = &cli.Command{
 	Usage: "Tron helper.",
 	Commands: []*cli.Command{
 		tronVanityCmd,
+		tronVanityCreate2Cmd,
 	},
 }
 
@@ -21,16 +23,90 @@ var tronVanityCmd = &cli.Command{
 	Name:    "vanity",
 	Aliases: []string{"v"},
 	Usage:   "Generate a vanity Tron address",
-	Flags:   model.VanityFlags(),
+	Flags:   append(model.VanitySecpFlags(), model.VanityMnemonicFlags()...),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		config, err := model.ParseVanityConfig(cmd)
+		config, err := model.ParseVanityConfig(cmd, model.Base58Alphabet)
 		if err != nil {
 			return err
 		}
+		if cmd.Bool("mnemonic") {
+			return tron.VanityMnemonicAddress(config, cmd.Int("words"))
+		}
 		return tron.VanityAddress(config)
 	},
 }
 
+// There is deliberately no vanity-create1: Tron derives contract addresses
+// from the deploying transaction's id, not from the deployer and a nonce.
+var tronVanityCreate2Cmd = &cli.Command{
+	Name:    "vanity-create2",
+	Aliases: []string{"vc2"},
+	Usage:   "Generate vanity TVM CREATE2 address.",
+	Flags: append(tronFactoryFlags(),
+		&cli.StringFlag{
+			Name:     "contract-bin",
+			Aliases:  []string{"cb"},
+			Usage:    "The contract bytecode.",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "constructor-args",
+			Aliases: []string{"ca"},
+			Usage:   "The constructor arguments. Format: type:value (e.g., uint256:123, address:0x...)",
+		},
+	),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityConfig(cmd, model.Base58Alphabet)
+		if err != nil {
+			return err
+		}
+
+		scheme := ethereum.Create2SaltScheme{Name: cmd.String("salt-scheme")}
+		if s := cmd.String("salt-address"); s != "" {
+			addr, err := tron.DecodeAddress(s)
+			if err != nil {
+				return err
+			}
+			scheme.Address = addr
+		}
+
+		return tron.VanityCreate2Address(config, cmd.String("deployer"), cmd.String("salt-prefix"), scheme, cmd.String("contract-bin"), cmd.StringSlice("constructor-args"))
+	},
+}
+
+// tronFactoryFlags are the shared factory flags with the addresses taken in
+// Tron's formats. CreateX (and with it --chain-id) isn't deployed on Tron, and
+// the zero-byte scores need hex addresses, so those flags are left out.
+func tronFactoryFlags() []cli.Flag {
+	var flags []cli.Flag
+	for _, flag := range model.VanityFactoryFlags() {
+		switch flag.Names()[0] {
+		case "chain-id", "leading-zero-bytes", "zero-bytes-total":
+			continue
+		case "deployer":
+			flag = &cli.StringFlag{
+				Name:     "deployer",
+				Aliases:  []string{"d"},
+				Usage:    "The deployer (factory) contract address, T... or 41-prefixed hex.",
+				Required: true,
+			}
+		case "salt-scheme":
+			flag = &cli.StringFlag{
+				Name:  "salt-scheme",
+				Usage: "How the bytes32 salt is built: keccak (keccak256 of salt-prefix + counter), counter (raw counter), address (--salt-address in the top 20 bytes).",
+				Value: ethereum.SaltSchemeKeccak,
+			}
+		case "salt-address":
+			flag = &cli.StringFlag{
+				Name:  "salt-address",
+				Usage: "Address for the top 20 bytes of the salt (address scheme), T... or 41-prefixed hex.",
+			}
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
 func init() {
 	rootCmd.Commands = append(rootCmd.Commands, tronCmd)
 }
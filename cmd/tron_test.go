@@ -0,0 +1,20 @@
+package cmd
+
+import "testing"
+
+func TestTronFactoryFlags(t *testing.T) {
+	names := map[string]bool{}
+	for _, flag := range tronFactoryFlags() {
+		names[flag.Names()[0]] = true
+	}
+	for _, name := range []string{"deployer", "salt-prefix", "salt-scheme", "salt-address", "contains"} {
+		if !names[name] {
+			t.Errorf("--%s missing", name)
+		}
+	}
+	for _, name := range []string{"chain-id", "leading-zero-bytes", "zero-bytes-total"} {
+		if names[name] {
+			t.Errorf("--%s can't work on Tron but is listed", name)
+		}
+	}
+}
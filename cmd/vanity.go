@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/naiba/nb/model"
+)
+
+var vanityCmd = &cli.Command{
+	Name:  "vanity",
+	Usage: "Vanity address helper.",
+	Commands: []*cli.Command{
+		vanityBenchCmd,
+	},
+}
+
+var vanityBenchCmd = &cli.Command{
+	Name:  "bench",
+	Usage: "Measure the vanity generators' keys per second across thread counts, and estimate the time to find a pattern.",
+	Flags: model.VanityBenchFlags(),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		config, err := model.ParseVanityBenchConfig(cmd)
+		if err != nil {
+			return err
+		}
+		return model.VanityBench(config)
+	},
+}
+
+func init() {
+	rootCmd.Commands = append(rootCmd.Commands, vanityCmd)
+}
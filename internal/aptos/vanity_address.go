@@ -0,0 +1,116 @@
+package aptos
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha3"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/naiba/nb/internal/ed25519keys"
+	"github.com/naiba/nb/model"
+)
+
+// ed25519Scheme is the authentication key scheme byte Aptos appends to an
+// ed25519 public key before hashing it into an account address.
+const ed25519Scheme = 0x00
+
+type AptosAddressData struct {
+	address    string // 64 lowercase hex characters, no 0x
+	privateKey ed25519.PrivateKey
+}
+
+// Address returns the 0x-prefixed account address.
+func (d *AptosAddressData) Address() string {
+	return "0x" + d.address
+}
+
+// PrivateKeyBytes returns the 32-byte ed25519 seed.
+func (d *AptosAddressData) PrivateKeyBytes() []byte {
+	return d.privateKey.Seed()
+}
+
+// ExportedPrivateKey returns the key in AIP-80 form, ed25519-priv-0x...,
+// which `aptos init --private-key` and the SDKs accept.
+func (d *AptosAddressData) ExportedPrivateKey() string {
+	return "ed25519-priv-0x" + hex.EncodeToString(d.PrivateKeyBytes())
+}
+
+// AptosAddressGenerator derives sha3-256(pubkey || scheme) addresses from the
+// shared ed25519 key sequence.
+type AptosAddressGenerator struct {
+	*ed25519keys.KeyGenerator
+}
+
+func NewAptosAddressGenerator() (*AptosAddressGenerator, error) {
+	kg, err := ed25519keys.NewKeyGenerator()
+	if err != nil {
+		return nil, err
+	}
+	return &AptosAddressGenerator{KeyGenerator: kg}, nil
+}
+
+func (g *AptosAddressGenerator) Generate() (string, interface{}, error) {
+	privateKey := g.Next()
+
+	var input [ed25519.PublicKeySize + 1]byte
+	copy(input[:], privateKey[32:])
+	input[ed25519.PublicKeySize] = ed25519Scheme
+	hash := sha3.Sum256(input[:])
+
+	address := hex.EncodeToString(hash[:])
+	return address, &AptosAddressData{
+		address:    address,
+		privateKey: privateKey,
+	}, nil
+}
+
+func VanityAddress(config *model.VanityConfig) error {
+	log.Printf("REMINDER: Aptos addresses are 64 lowercase hexadecimal characters (0-9, a-f) after 0x")
+
+	const validHexChars = "0123456789abcdefABCDEF"
+	for _, pattern := range config.ContainsList() {
+		for _, char := range pattern {
+			if !strings.ContainsRune(validHexChars, char) {
+				return fmt.Errorf("contains illegal character: %c (Aptos addresses only contain 0-9, a-f)", char)
+			}
+		}
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask only applies to 20-byte addresses")
+	}
+	if err := config.PlaintextKeystore("AIP-80 private key"); err != nil {
+		return err
+	}
+
+	generator, err := NewAptosAddressGenerator()
+	if err != nil {
+		return err
+	}
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.HexAlphabet).WithLowercase()
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*AptosAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		if config.KeystoreDir != "" {
+			path, err := model.WriteSecretFile(config.KeystoreDir, data.Address()+".key", []byte(data.ExportedPrivateKey()+"\n"))
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.Address())
+				log.Printf("Key File: %s (aptos init --private-key-file)", path)
+			})
+		}
+
+		record.PrivateKey = data.ExportedPrivateKey()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Private Key: %s", record.PrivateKey)
+			log.Printf("Import: aptos init --private-key %s", record.PrivateKey)
+		})
+	})
+}
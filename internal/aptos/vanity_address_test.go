@@ -0,0 +1,41 @@
+package aptos
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/naiba/nb/internal/ed25519keys"
+	"github.com/naiba/nb/model"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestAptosAddressGenerator_AddressDerivation(t *testing.T) {
+	gen := &AptosAddressGenerator{KeyGenerator: ed25519keys.NewKeyGeneratorFromSeed([32]byte{0x42})}
+	for i := 0; i < 100; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := data.(*AptosAddressData)
+
+		pub := ed25519.NewKeyFromSeed(d.PrivateKeyBytes()).Public().(ed25519.PublicKey)
+		h := sha3.New256()
+		h.Write(pub)
+		h.Write([]byte{0x00})
+		if want := hex.EncodeToString(h.Sum(nil)); addr != want || d.Address() != "0x"+want {
+			t.Fatalf("address = %s, want %s", addr, want)
+		}
+		if want := "ed25519-priv-0x" + hex.EncodeToString(d.PrivateKeyBytes()); d.ExportedPrivateKey() != want {
+			t.Fatalf("exported key = %s, want %s", d.ExportedPrivateKey(), want)
+		}
+	}
+}
+
+func TestVanityAddress_RejectsPassphraseFile(t *testing.T) {
+	config := &model.VanityConfig{Contains: "abc", KeystoreDir: t.TempDir(), PassphraseFile: "pass.txt"}
+	if err := VanityAddress(config); err == nil || !strings.Contains(err.Error(), "plaintext") {
+		t.Errorf("err = %v, want --passphrase-file rejected", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Package bech32 encodes bech32 and bech32m strings (BIP-173, BIP-350):
+// Bitcoin segwit and Cosmos addresses, and Sui private keys.
+package bech32
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Charset is the bech32 data alphabet (BIP-173). It has no 1, b, i or
+// o, and addresses use one case only.
+const Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants: bech32 for segwit v0 and Cosmos, bech32m (BIP-350)
+// for segwit v1+.
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Encoder encodes bech32 strings for one human-readable part, with the
+// HRP's share of the checksum computed once up front.
+type Encoder struct {
+	hrp    string
+	hrpChk uint32
+}
+
+// NewEncoder returns an encoder for hrp, which must be lowercase.
+func NewEncoder(hrp string) *Encoder {
+	chk := uint32(1)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymod(chk, hrp[i]>>5)
+	}
+	chk = polymod(chk, 0)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymod(chk, hrp[i]&31)
+	}
+	return &Encoder{hrp: hrp, hrpChk: chk}
+}
+
+// Encode returns hrp + "1" + data regrouped into 5-bit words + checksum.
+// A witness version, if any, goes first in data5 (already 5-bit).
+func (e *Encoder) Encode(data5 []byte, data []byte, bech32m bool) string {
+	var wordsBuf [96]byte
+	words := append(wordsBuf[:0], data5...)
+	// Regroup 8-bit bytes into 5-bit words, zero-padding the last one.
+	var acc uint32
+	var bits uint
+	for _, b := range data {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			words = append(words, byte(acc>>bits)&31)
+		}
+	}
+	if bits > 0 {
+		words = append(words, byte(acc<<(5-bits))&31)
+	}
+
+	var buf [128]byte
+	out := append(buf[:0], e.hrp...)
+	out = append(out, '1')
+	chk := e.hrpChk
+	for _, v := range words {
+		chk = polymod(chk, v)
+		out = append(out, Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		chk = polymod(chk, 0)
+	}
+	if bech32m {
+		chk ^= bech32mConst
+	} else {
+		chk ^= bech32Const
+	}
+	for i := 0; i < 6; i++ {
+		out = append(out, Charset[(chk>>(5*(5-i)))&31])
+	}
+	return string(out)
+}
+
+func polymod(chk uint32, v byte) uint32 {
+	top := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+	for i := 0; i < 5; i++ {
+		if (top>>i)&1 == 1 {
+			chk ^= generator[i]
+		}
+	}
+	return chk
+}
+
+// ValidateContains checks that each pattern only uses the bech32
+// charset (either case), apart from an optional leading prefix — the
+// HRP and separator, plus the witness version for segwit ("bc1q").
+func ValidateContains(prefix string, patterns ...string) error {
+	for _, pattern := range patterns {
+		if len(pattern) >= len(prefix) && strings.EqualFold(pattern[:len(prefix)], prefix) {
+			pattern = pattern[len(prefix):]
+		}
+		for _, char := range pattern {
+			if !strings.ContainsRune(Charset, char) && !strings.ContainsRune(strings.ToUpper(Charset), char) {
+				return fmt.Errorf("contains illegal character: %c (bech32 addresses only use %s: no 1, b, i, o)", char, Charset)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package bech32
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncoder_BIP350(t *testing.T) {
+	program, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6")
+	want := "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y"
+	if got := NewEncoder("bc").Encode([]byte{1}, program, true); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidateContains(t *testing.T) {
+	if err := ValidateContains("bc1q", "bc1qqz", "QQZ"); err != nil {
+		t.Errorf("valid patterns rejected: %v", err)
+	}
+	if err := ValidateContains("bc1q", "bc1qb"); err == nil {
+		t.Error("'b' accepted")
+	}
+}
@@ -0,0 +1,17 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+
+	"github.com/mr-tron/base58"
+)
+
+// Base58Check encodes version || payload || first 4 bytes of
+// sha256(sha256(version || payload)).
+func Base58Check(version byte, payload []byte) string {
+	var buf [1 + 33 + 4]byte // fits the largest payload here, a WIF key
+	b := append(append(buf[:0], version), payload...)
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return base58.Encode(append(b, second[:4]...))
+}
@@ -0,0 +1,239 @@
+package bitcoin
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	ethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/model"
+)
+
+// Address types accepted by --type.
+const (
+	AddressTypeP2PKH  = "p2pkh"  // legacy base58check, 1...
+	AddressTypeP2WPKH = "p2wpkh" // native segwit v0, bech32 bc1q...
+	AddressTypeP2TR   = "p2tr"   // taproot key path (BIP-86), bech32m bc1p...
+)
+
+// Mainnet encoding parameters.
+const (
+	p2pkhVersion = 0x00
+	wifVersion   = 0x80
+	segwitHRP    = "bc"
+)
+
+// See internal/ethereum/secp_keygen.go for why the cgo curve.
+var curve = secp256k1.S256()
+
+var (
+	// tapTweakTag is sha256("TapTweak"), the BIP-340 tagged hash prefix.
+	tapTweakTag = sha256.Sum256([]byte("TapTweak"))
+
+	mainnetSegwit = bech32.NewEncoder(segwitHRP)
+)
+
+type BitcoinAddressData struct {
+	address     string
+	addressType string
+	seed        [32]byte
+}
+
+// PrivateKeyBytes returns the 32-byte private key. For taproot it is the
+// internal key; wallets apply the BIP-86 tweak themselves.
+func (d *BitcoinAddressData) PrivateKeyBytes() []byte {
+	return d.seed[:]
+}
+
+// WIF returns the private key in mainnet wallet import format, flagged as
+// compressed since every address type here uses the compressed public key.
+func (d *BitcoinAddressData) WIF() string {
+	return Base58Check(wifVersion, append(d.seed[:], 0x01))
+}
+
+// Descriptor returns the output descriptor Bitcoin Core's importdescriptors
+// takes for the key, e.g. wpkh(<WIF>).
+func (d *BitcoinAddressData) Descriptor() string {
+	switch d.addressType {
+	case AddressTypeP2WPKH:
+		return "wpkh(" + d.WIF() + ")"
+	case AddressTypeP2TR:
+		return "tr(" + d.WIF() + ")"
+	default:
+		return "pkh(" + d.WIF() + ")"
+	}
+}
+
+// BitcoinAddressGenerator builds mainnet addresses on top of the shared
+// ethereum.SecpKeyGenerator, which hands over the raw public key.
+type BitcoinAddressGenerator struct {
+	*ethereum.SecpKeyGenerator
+	addressType string
+}
+
+func NewBitcoinAddressGenerator(addressType string) (*BitcoinAddressGenerator, error) {
+	if err := validateAddressType(addressType); err != nil {
+		return nil, err
+	}
+	kg, err := ethereum.NewSecpKeyGenerator()
+	if err != nil {
+		return nil, err
+	}
+	return &BitcoinAddressGenerator{SecpKeyGenerator: kg, addressType: addressType}, nil
+}
+
+func validateAddressType(addressType string) error {
+	switch addressType {
+	case AddressTypeP2PKH, AddressTypeP2WPKH, AddressTypeP2TR:
+		return nil
+	default:
+		return fmt.Errorf("address type must be one of: %s, %s, %s", AddressTypeP2PKH, AddressTypeP2WPKH, AddressTypeP2TR)
+	}
+}
+
+func (g *BitcoinAddressGenerator) Generate() (string, interface{}, error) {
+	seed, pub, err := g.NextPublicKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var address string
+	switch g.addressType {
+	case AddressTypeP2TR:
+		outputKey := taprootOutputKey(pub)
+		address = mainnetSegwit.Encode([]byte{1}, outputKey[:], true)
+	case AddressTypeP2WPKH:
+		hash := ethereum.Hash160(ethereum.CompressPublicKey(pub))
+		address = mainnetSegwit.Encode([]byte{0}, hash[:], false)
+	default:
+		hash := ethereum.Hash160(ethereum.CompressPublicKey(pub))
+		address = Base58Check(p2pkhVersion, hash[:])
+	}
+	return address, &BitcoinAddressData{
+		address:     address,
+		addressType: g.addressType,
+		seed:        seed,
+	}, nil
+}
+
+// taprootOutputKey applies the BIP-86 key-path tweak: with P the internal key
+// lifted to even Y, Q = P + int(tagged_hash("TapTweak", x(P)))·G, and the
+// address commits to x(Q).
+func taprootOutputKey(pub [64]byte) (outputKey [32]byte) {
+	x := new(big.Int).SetBytes(pub[:32])
+	y := new(big.Int).SetBytes(pub[32:])
+	if y.Bit(0) == 1 {
+		y.Sub(curve.P, y)
+	}
+
+	h := sha256.New()
+	h.Write(tapTweakTag[:])
+	h.Write(tapTweakTag[:])
+	h.Write(pub[:32])
+	var tweak [32]byte
+	h.Sum(tweak[:0])
+	// t >= N has odds of about 2^-128; BIP-86 wallets fail the same way.
+	tx, ty := curve.ScalarBaseMult(tweak[:])
+	qx, _ := curve.Add(x, y, tx, ty)
+	ethmath.ReadBits(qx, outputKey[:])
+	return outputKey
+}
+
+// ValidateContains checks the patterns against the address type's alphabet:
+// base58 for P2PKH, the bech32 charset (after an optional "bc1q"/"bc1p") for
+// segwit.
+func ValidateContains(addressType string, patterns ...string) error {
+	if addressType == AddressTypeP2PKH {
+		for _, pattern := range patterns {
+			for _, char := range pattern {
+				if !strings.ContainsRune(model.Base58Alphabet, char) {
+					return fmt.Errorf("contains illegal character: %c (legacy addresses use Base58: excludes 0, O, I, l)", char)
+				}
+			}
+		}
+		return nil
+	}
+	return bech32.ValidateContains(fixedPrefix(addressType), patterns...)
+}
+
+// fixedPrefix is what every address of the type starts with.
+func fixedPrefix(addressType string) string {
+	switch addressType {
+	case AddressTypeP2WPKH:
+		return segwitHRP + "1q"
+	case AddressTypeP2TR:
+		return segwitHRP + "1p"
+	default:
+		return "1"
+	}
+}
+
+func VanityAddress(config *model.VanityConfig, addressType string) error {
+	if err := validateAddressType(addressType); err != nil {
+		return err
+	}
+	if err := ValidateContains(addressType, config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask does not apply to base58 and bech32 Bitcoin addresses")
+	}
+	if err := config.PlaintextKeystore("WIF"); err != nil {
+		return err
+	}
+
+	prefix := fixedPrefix(addressType)
+	if addressType == AddressTypeP2PKH {
+		log.Printf("REMINDER: Legacy Bitcoin addresses use Base58 encoding (excludes 0, O, I, l) and are case-sensitive")
+	} else {
+		log.Printf("REMINDER: Bech32 addresses are lowercase and use %s; patterns match case-insensitively", bech32.Charset)
+	}
+	if config.Mode == model.VanityModePrefix {
+		for _, pattern := range config.ContainsList() {
+			if !strings.HasPrefix(strings.ToLower(pattern), strings.ToLower(prefix)) {
+				log.Printf("WARNING: %s addresses always start with '%s'. Your search pattern '%s' will need to match after the '%s'", addressType, prefix, pattern, prefix)
+			}
+		}
+	}
+
+	generator, err := NewBitcoinAddressGenerator(addressType)
+	if err != nil {
+		return err
+	}
+
+	searcher := model.NewVanitySearcher(config, generator).WithFixedPrefix(prefix)
+	if addressType == AddressTypeP2PKH {
+		searcher.WithAlphabet(model.Base58Alphabet)
+	} else {
+		searcher.WithAlphabet(bech32.Charset).WithLowercase()
+	}
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*BitcoinAddressData)
+		record := model.NewVanityRecord(data.address, result)
+		if config.KeystoreDir != "" {
+			path, err := model.WriteSecretFile(config.KeystoreDir, data.address+".wif", []byte(data.WIF()+"\n"))
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.address)
+				log.Printf("WIF File: %s", path)
+			})
+		}
+
+		record.PrivateKey = data.WIF()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.address)
+			log.Printf("Private Key (WIF): %s", data.WIF())
+			log.Printf("Descriptor: %s", data.Descriptor())
+		})
+	})
+}
@@ -0,0 +1,124 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/model"
+)
+
+// newTestGenerator starts at private key 1: the zero seed reduces to zero and
+// the generator adds one.
+func newTestGenerator(addressType string) *BitcoinAddressGenerator {
+	return &BitcoinAddressGenerator{
+		SecpKeyGenerator: ethereum.NewSecpKeyGeneratorFromSeed([32]byte{}),
+		addressType:      addressType,
+	}
+}
+
+func TestBitcoinAddressGenerator_KnownKey(t *testing.T) {
+	cases := map[string]string{
+		AddressTypeP2PKH:  "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		AddressTypeP2WPKH: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+	}
+	for addressType, want := range cases {
+		addr, data, err := newTestGenerator(addressType).Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr != want {
+			t.Fatalf("%s of key 1 = %s, want %s", addressType, addr, want)
+		}
+		d := data.(*BitcoinAddressData)
+		if wif := d.WIF(); wif != "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn" {
+			t.Fatalf("WIF of key 1 = %s", wif)
+		}
+	}
+}
+
+// TestTaprootOutputKey_BIP86 uses the first receiving address of the BIP-86
+// test vectors.
+func TestTaprootOutputKey_BIP86(t *testing.T) {
+	internalKey, _ := hex.DecodeString("02cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	for _, prefix := range []byte{0x02, 0x03} {
+		// The tweak lifts the internal key to even Y, so both parities of the
+		// same X give the same output key.
+		internalKey[0] = prefix
+		pk, err := crypto.DecompressPubkey(internalKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pub [64]byte
+		copy(pub[:], crypto.FromECDSAPub(pk)[1:])
+
+		outputKey := taprootOutputKey(pub)
+		if got := hex.EncodeToString(outputKey[:]); got != "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c" {
+			t.Fatalf("output key = %s", got)
+		}
+		if addr := mainnetSegwit.Encode([]byte{1}, outputKey[:], true); addr != "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr" {
+			t.Fatalf("address = %s", addr)
+		}
+	}
+}
+
+func TestValidateContains(t *testing.T) {
+	cases := []struct {
+		addressType, pattern string
+		ok                   bool
+	}{
+		{AddressTypeP2PKH, "1Bob", true},
+		{AddressTypeP2PKH, "10l", false},
+		{AddressTypeP2WPKH, "bc1qdead", true},
+		{AddressTypeP2WPKH, "BC1QDEAD", true},
+		{AddressTypeP2WPKH, "dead", true},
+		{AddressTypeP2WPKH, "beef", false}, // no b in bech32
+		{AddressTypeP2TR, "bc1pq0", true},
+		{AddressTypeP2TR, "bc1qq0", false}, // the q is fine, the 1 and b are not
+	}
+	for _, c := range cases {
+		err := ValidateContains(c.addressType, c.pattern)
+		if (err == nil) != c.ok {
+			t.Errorf("ValidateContains(%s, %q) = %v, want ok=%v", c.addressType, c.pattern, err, c.ok)
+		}
+	}
+}
+
+func TestBitcoinAddressGenerator_Prefixes(t *testing.T) {
+	for _, addressType := range []string{AddressTypeP2PKH, AddressTypeP2WPKH, AddressTypeP2TR} {
+		gen, err := NewBitcoinAddressGenerator(addressType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 50; i++ {
+			addr, _, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(addr, fixedPrefix(addressType)) {
+				t.Fatalf("%s address %s lacks prefix %s", addressType, addr, fixedPrefix(addressType))
+			}
+		}
+	}
+	if _, err := NewBitcoinAddressGenerator("p2sh"); err == nil {
+		t.Fatal("unknown address type accepted")
+	}
+}
+
+func TestVanityAddress_RejectsPassphraseFile(t *testing.T) {
+	config := &model.VanityConfig{Contains: "1abc", KeystoreDir: t.TempDir(), PassphraseFile: "pass.txt"}
+	if err := VanityAddress(config, AddressTypeP2PKH); err == nil || !strings.Contains(err.Error(), "plaintext") {
+		t.Errorf("err = %v, want --passphrase-file rejected", err)
+	}
+}
+
+func TestVanityAddress_RejectsMask(t *testing.T) {
+	for _, addressType := range []string{AddressTypeP2PKH, AddressTypeP2WPKH} {
+		config := &model.VanityConfig{Contains: "qqq", Mask: []byte{0xff}, MaskValue: []byte{0}}
+		if err := VanityAddress(config, addressType); err == nil || !strings.Contains(err.Error(), "--mask") {
+			t.Errorf("%s: err = %v, want --mask rejected", addressType, err)
+		}
+	}
+}
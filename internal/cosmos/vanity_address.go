@@ -0,0 +1,129 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/model"
+)
+
+type CosmosAddressData struct {
+	address string
+	seed    [32]byte
+}
+
+// PrivateKeyBytes returns the 32-byte secp256k1 private key.
+func (d *CosmosAddressData) PrivateKeyBytes() []byte {
+	return d.seed[:]
+}
+
+// CosmosAddressGenerator builds bech32(hrp, ripemd160(sha256(compressed
+// pubkey))) addresses, the Cosmos SDK secp256k1 account format, on top of the
+// shared ethereum.SecpKeyGenerator.
+type CosmosAddressGenerator struct {
+	*ethereum.SecpKeyGenerator
+	encoder *bech32.Encoder
+}
+
+func NewCosmosAddressGenerator(hrp string) (*CosmosAddressGenerator, error) {
+	if err := ValidateHRP(hrp); err != nil {
+		return nil, err
+	}
+	kg, err := ethereum.NewSecpKeyGenerator()
+	if err != nil {
+		return nil, err
+	}
+	return &CosmosAddressGenerator{SecpKeyGenerator: kg, encoder: bech32.NewEncoder(hrp)}, nil
+}
+
+func (g *CosmosAddressGenerator) Generate() (string, interface{}, error) {
+	seed, pub, err := g.NextPublicKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	hash := ethereum.Hash160(ethereum.CompressPublicKey(pub))
+
+	address := g.encoder.Encode(nil, hash[:], false)
+	return address, &CosmosAddressData{
+		address: address,
+		seed:    seed,
+	}, nil
+}
+
+// ValidateHRP accepts lowercase alphanumeric human-readable parts such as
+// cosmos, osmo or celestia.
+func ValidateHRP(hrp string) error {
+	if hrp == "" {
+		return fmt.Errorf("--hrp is required")
+	}
+	for _, c := range hrp {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return fmt.Errorf("invalid hrp %q: use lowercase letters and digits", hrp)
+		}
+	}
+	return nil
+}
+
+func VanityAddress(config *model.VanityConfig, hrp string) error {
+	if err := ValidateHRP(hrp); err != nil {
+		return err
+	}
+	prefix := hrp + "1"
+	if err := bech32.ValidateContains(prefix, config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask does not apply to bech32 Cosmos addresses")
+	}
+	if err := config.PlaintextKeystore("hex private key"); err != nil {
+		return err
+	}
+	log.Printf("REMINDER: Cosmos addresses are lowercase bech32 and use %s; patterns match case-insensitively", bech32.Charset)
+	if config.Mode == model.VanityModePrefix {
+		for _, pattern := range config.ContainsList() {
+			if !strings.HasPrefix(strings.ToLower(pattern), prefix) {
+				log.Printf("WARNING: %s addresses always start with '%s'. Your search pattern '%s' will need to match after the '%s'", hrp, prefix, pattern, prefix)
+			}
+		}
+	}
+
+	generator, err := NewCosmosAddressGenerator(hrp)
+	if err != nil {
+		return err
+	}
+	searcher := model.NewVanitySearcher(config, generator).
+		WithAlphabet(bech32.Charset).
+		WithLowercase().
+		WithFixedPrefix(prefix)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*CosmosAddressData)
+		record := model.NewVanityRecord(data.address, result)
+		if config.KeystoreDir != "" {
+			// Plain hex, what `keys import-hex` takes.
+			path, err := model.WriteSecretFile(config.KeystoreDir, data.address+".hex", []byte(hex.EncodeToString(data.PrivateKeyBytes())+"\n"))
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.address)
+				log.Printf("Key File: %s", path)
+			})
+		}
+
+		privateKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
+		record.PrivateKey = privateKeyHex
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.address)
+			log.Printf("Private Key (hex): %s", privateKeyHex)
+			log.Printf("Import: <chain>d keys import-hex <name> %s", privateKeyHex)
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/model"
+)
+
+func TestCosmosAddressGenerator_KnownKey(t *testing.T) {
+	// The zero seed yields private key 1, whose compressed public key hashes
+	// to 751e76e8... (the BIP-173 P2WPKH test vector).
+	hash, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+	for _, hrp := range []string{"cosmos", "osmo", "celestia"} {
+		gen := &CosmosAddressGenerator{
+			SecpKeyGenerator: ethereum.NewSecpKeyGeneratorFromSeed([32]byte{}),
+			encoder:          bech32.NewEncoder(hrp),
+		}
+		addr, _, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := bech32.NewEncoder(hrp).Encode(nil, hash, false); addr != want {
+			t.Fatalf("%s address of key 1 = %s, want %s", hrp, addr, want)
+		}
+		// 20 bytes are 32 data characters, plus the 6-character checksum.
+		if !strings.HasPrefix(addr, hrp+"1") || len(addr) != len(hrp)+1+32+6 {
+			t.Fatalf("malformed address %s", addr)
+		}
+	}
+}
+
+func TestValidateHRP(t *testing.T) {
+	for hrp, ok := range map[string]bool{"cosmos": true, "osmo": true, "sei": true, "": false, "Osmo": false, "os-mo": false} {
+		if err := ValidateHRP(hrp); (err == nil) != ok {
+			t.Errorf("ValidateHRP(%q) = %v, want ok=%v", hrp, err, ok)
+		}
+	}
+}
+
+func TestVanityAddress_RejectsPassphraseFile(t *testing.T) {
+	config := &model.VanityConfig{Contains: "cosmos1qq", KeystoreDir: t.TempDir(), PassphraseFile: "pass.txt"}
+	if err := VanityAddress(config, "cosmos"); err == nil || !strings.Contains(err.Error(), "plaintext") {
+		t.Errorf("err = %v, want --passphrase-file rejected", err)
+	}
+}
+
+func TestVanityAddress_RejectsMask(t *testing.T) {
+	config := &model.VanityConfig{Contains: "cosmos1qq", Mask: []byte{0xff}, MaskValue: []byte{0}}
+	if err := VanityAddress(config, "cosmos"); err == nil || !strings.Contains(err.Error(), "--mask") {
+		t.Errorf("err = %v, want --mask rejected", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package ed25519keys is the key sequence behind the ed25519 vanity
+// searches.
+package ed25519keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/naiba/nb/model"
+)
+
+// KeyGenerator produces ed25519 keys from (baseSeed + counter) mod
+// 2^256. ed25519 accepts any 32-byte seed, so no modular reduction is needed.
+// Shared by the Solana, Sui and Aptos generators, which differ only in how
+// they hash the public key into an address.
+type KeyGenerator struct {
+	counter   atomic.Uint64
+	baseWords [4]uint64
+}
+
+// NewKeyGenerator seeds from crypto/rand.
+func NewKeyGenerator() (*KeyGenerator, error) {
+	var baseSeed [32]byte
+	l, err := rand.Read(baseSeed[:])
+	if err != nil || l != 32 {
+		return nil, fmt.Errorf("failed to generate random seed: %v", err)
+	}
+	return NewKeyGeneratorFromSeed(baseSeed), nil
+}
+
+// NewKeyGeneratorFromSeed starts the key sequence at seed.
+func NewKeyGeneratorFromSeed(seed [32]byte) *KeyGenerator {
+	return &KeyGenerator{baseWords: [4]uint64{
+		binary.BigEndian.Uint64(seed[0:8]),
+		binary.BigEndian.Uint64(seed[8:16]),
+		binary.BigEndian.Uint64(seed[16:24]),
+		binary.BigEndian.Uint64(seed[24:32]),
+	}}
+}
+
+// Next returns the next private key. Threadsafe via the atomic counter.
+func (g *KeyGenerator) Next() ed25519.PrivateKey {
+	counter := g.counter.Add(1) - 1
+
+	// 256-bit add with carry; wrap at 2^256 is fine for ed25519 seeds.
+	var r [4]uint64
+	var carry uint64
+	r[3], carry = bits.Add64(g.baseWords[3], counter, 0)
+	r[2], carry = bits.Add64(g.baseWords[2], 0, carry)
+	r[1], carry = bits.Add64(g.baseWords[1], 0, carry)
+	r[0], _ = bits.Add64(g.baseWords[0], 0, carry)
+
+	var seed [32]byte
+	binary.BigEndian.PutUint64(seed[0:8], r[0])
+	binary.BigEndian.PutUint64(seed[8:16], r[1])
+	binary.BigEndian.PutUint64(seed[16:24], r[2])
+	binary.BigEndian.PutUint64(seed[24:32], r[3])
+
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+// Checkpoint implements model.ResumableGenerator.
+func (g *KeyGenerator) Checkpoint() model.GeneratorCheckpoint {
+	var seed [32]byte
+	binary.BigEndian.PutUint64(seed[0:8], g.baseWords[0])
+	binary.BigEndian.PutUint64(seed[8:16], g.baseWords[1])
+	binary.BigEndian.PutUint64(seed[16:24], g.baseWords[2])
+	binary.BigEndian.PutUint64(seed[24:32], g.baseWords[3])
+	return model.GeneratorCheckpoint{
+		Seed:    hex.EncodeToString(seed[:]),
+		Counter: g.counter.Load(),
+	}
+}
+
+// Restore implements model.ResumableGenerator.
+func (g *KeyGenerator) Restore(cp model.GeneratorCheckpoint) error {
+	seed, err := hex.DecodeString(cp.Seed)
+	if err != nil || len(seed) != 32 {
+		return fmt.Errorf("invalid checkpoint seed %q", cp.Seed)
+	}
+	g.baseWords = NewKeyGeneratorFromSeed([32]byte(seed)).baseWords
+	g.counter.Store(cp.Counter)
+	return nil
+}
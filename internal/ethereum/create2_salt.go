@@ -0,0 +1,112 @@
+package ethereum
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CREATE2 salt schemes accepted by --salt-scheme.
+const (
+	SaltSchemeKeccak  = "keccak"  // keccak256(saltPrefix + hex(counter)), the historical default
+	SaltSchemeCounter = "counter" // raw bytes32 counter
+	SaltSchemeAddress = "address" // your address in the top 20 bytes, counter below
+	SaltSchemeCreateX = "createx" // CreateX layout: caller(20) || protection flag(1) || counter(11)
+)
+
+// CreateXAddress is CreateX's deployment address, the same on every chain.
+var CreateXAddress = common.HexToAddress("0xba5Ed099633D3B313e4D5F7bdc1305d3c28ba5Ed")
+
+// Create2SaltScheme selects how the 32-byte salt handed to the factory is
+// built from the search counter.
+//
+// The raw schemes give the factory-ready bytes32 directly: the deterministic
+// deployment proxy and the Safe singleton factory take any salt, while
+// factories like ImmutableCreate2Factory or CreateX require the caller in
+// the top 20 bytes (SaltSchemeAddress, SaltSchemeCreateX).
+type Create2SaltScheme struct {
+	Name string
+
+	// Address fills the top 20 bytes for SaltSchemeAddress and is the
+	// msg.sender for SaltSchemeCreateX, where the zero address disables
+	// CreateX's permissioned deploy protection.
+	Address common.Address
+
+	// ChainID, for SaltSchemeCreateX, turns on cross-chain redeploy
+	// protection (flag byte 0x01): the address then only exists on this chain.
+	ChainID uint64
+}
+
+// validate checks the scheme and resolves its default name.
+func (s *Create2SaltScheme) validate() error {
+	switch s.Name {
+	case "":
+		s.Name = SaltSchemeKeccak
+	case SaltSchemeKeccak, SaltSchemeCounter, SaltSchemeCreateX:
+	case SaltSchemeAddress:
+		if s.Address == (common.Address{}) {
+			return fmt.Errorf("salt scheme %s requires --salt-address", SaltSchemeAddress)
+		}
+	default:
+		return fmt.Errorf("salt scheme must be one of: %s, %s, %s, %s", SaltSchemeKeccak, SaltSchemeCounter, SaltSchemeAddress, SaltSchemeCreateX)
+	}
+	if s.Name != SaltSchemeCreateX && s.ChainID != 0 {
+		return fmt.Errorf("--chain-id only applies to salt scheme %s", SaltSchemeCreateX)
+	}
+	return nil
+}
+
+// template returns the fixed part of a raw salt; the counter is written
+// big-endian into the last 8 bytes.
+func (s *Create2SaltScheme) template() (salt [32]byte) {
+	switch s.Name {
+	case SaltSchemeAddress:
+		copy(salt[:20], s.Address[:])
+	case SaltSchemeCreateX:
+		copy(salt[:20], s.Address[:])
+		if s.ChainID != 0 {
+			salt[20] = 0x01
+		}
+	}
+	return salt
+}
+
+// rawSalt builds the raw salt for counter.
+func rawSalt(template [32]byte, counter uint64) [32]byte {
+	binary.BigEndian.PutUint64(template[24:], counter)
+	return template
+}
+
+// createXGuard mirrors CreateX's _guard for the salts SaltSchemeCreateX
+// produces, returning the salt CreateX actually feeds to CREATE2.
+func (s *Create2SaltScheme) createXGuard(salt [32]byte) [32]byte {
+	var chainID [32]byte
+	binary.BigEndian.PutUint64(chainID[24:], s.ChainID)
+	sender := common.BytesToHash(s.Address[:])
+
+	switch {
+	case s.Address != (common.Address{}) && s.ChainID != 0:
+		// keccak256(abi.encode(msg.sender, block.chainid, salt))
+		return crypto.Keccak256Hash(sender[:], chainID[:], salt[:])
+	case s.Address != (common.Address{}):
+		return crypto.Keccak256Hash(sender[:], salt[:])
+	case s.ChainID != 0:
+		return crypto.Keccak256Hash(chainID[:], salt[:])
+	default:
+		// keccak256(abi.encode(salt))
+		return crypto.Keccak256Hash(salt[:])
+	}
+}
+
+func (s *Create2SaltScheme) String() string {
+	switch s.Name {
+	case SaltSchemeAddress:
+		return fmt.Sprintf("%s(%s)", s.Name, s.Address.Hex())
+	case SaltSchemeCreateX:
+		return fmt.Sprintf("%s(%s, chain %d)", s.Name, s.Address.Hex(), s.ChainID)
+	default:
+		return s.Name
+	}
+}
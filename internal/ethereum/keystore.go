@@ -0,0 +1,40 @@
+package ethereum
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/naiba/nb/model"
+)
+
+// Scrypt cost for keystore files; vars so tests can use the light settings.
+var keystoreScryptN, keystoreScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+
+// WriteKeystore encrypts a secp256k1 private key as a Web3 Secret Storage
+// (keystore v3) file in dir and returns its path. An empty name uses geth's
+// UTC--<time>--<address> scheme so the file drops straight into a keystore
+// directory; Tron passes its base58 address instead.
+func WriteKeystore(dir, name string, privateKey []byte, passphrase string) (string, error) {
+	pk, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return "", err
+	}
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}
+	blob, err := keystore.EncryptKey(key, passphrase, keystoreScryptN, keystoreScryptP)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt key: %w", err)
+	}
+	if name == "" {
+		ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+		name = "UTC--" + ts + "--" + strings.ToLower(key.Address.Hex()[2:])
+	}
+	return model.WriteSecretFile(dir, name, blob)
+}
@@ -0,0 +1,59 @@
+package ethereum
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestWriteKeystore_DecryptsToSameKey(t *testing.T) {
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+	defer func() {
+		keystoreScryptN, keystoreScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+	}()
+
+	_, data, err := newEthereumAddressGeneratorFromSeed([32]byte{0x07}).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := data.(*EthereumAddressData)
+
+	dir := t.TempDir()
+	path, err := WriteKeystore(dir, "", d.seed[:], "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "UTC--") || !strings.HasSuffix(path, strings.ToLower(d.Address()[2:])) {
+		t.Fatalf("unexpected keystore file name %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("keystore mode = %o, want 600", perm)
+	}
+
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keystore.DecryptKey(blob, "wrong"); err == nil {
+		t.Fatal("decrypt with wrong passphrase must fail")
+	}
+	key, err := keystore.DecryptKey(blob, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := crypto.PubkeyToAddress(key.PrivateKey.PublicKey).Hex(); got != d.Address() {
+		t.Fatalf("decrypted key address = %s, want %s", got, d.Address())
+	}
+
+	if _, err := WriteKeystore(dir, filepath.Base(path), d.seed[:], "correct horse"); err == nil {
+		t.Fatal("expected refusal to overwrite an existing key file")
+	}
+}
@@ -1,8 +1,11 @@
 package ethereum
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,6 +15,8 @@ import (
 	ethmath "github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/naiba/nb/model"
+	"golang.org/x/crypto/ripemd160"
 )
 
 // Cached at package init to avoid per-call lookups and the
@@ -37,14 +42,19 @@ var (
 )
 
 // SecpKeyGenerator is the shared secp256k1 address mining pipeline used by
-// EOA, CREATE1, and Tron vanity generators. Each Next() returns
+// EOA, CREATE1, Tron, Bitcoin and Cosmos vanity generators. Each Next() returns
 //
 //	(seed ∈ [1, N-1], ethAddr = keccak256(pubkey)[12:])
 //
-// derived deterministically from the base seed + monotonic counter.
+// derived deterministically from the base seed + monotonic counter; chains
+// with other address hashes use NextPublicKey.
 type SecpKeyGenerator struct {
 	counter   atomic.Uint64
 	baseWords [4]uint64
+
+	// Split-key mode: addresses are derived from splitPub + seed·G, so the
+	// seed is only a partial key (see SetSplitKey).
+	splitPub *ecdsa.PublicKey
 }
 
 // NewSecpKeyGenerator seeds from crypto/rand.
@@ -83,6 +93,19 @@ func newSecpKeyGeneratorFromSeed(seed [32]byte) *SecpKeyGenerator {
 // reintroduce int64 casts or big.Int here without re-adding the regression
 // tests in *_CounterOverflowBoundary.
 func (g *SecpKeyGenerator) Next() (seed [32]byte, addr [20]byte, err error) {
+	seed, pub, err := g.NextPublicKey()
+	if err != nil {
+		return seed, addr, err
+	}
+	// Keccak256Hash returns a value-type common.Hash so the output doesn't escape.
+	hash := crypto.Keccak256Hash(pub[:])
+	copy(addr[:], hash[12:32])
+	return seed, addr, nil
+}
+
+// NextPublicKey returns the next seed and its public key as X || Y (32 bytes
+// each, big-endian), before any address hashing. Same counter as Next.
+func (g *SecpKeyGenerator) NextPublicKey() (seed [32]byte, pub [64]byte, err error) {
 	counter := g.counter.Add(1) - 1
 
 	// seed = (base + counter) mod (N-1), then + 1 -> in [1, N-1].
@@ -105,15 +128,36 @@ func (g *SecpKeyGenerator) Next() (seed [32]byte, addr [20]byte, err error) {
 
 	x, y := curve.ScalarBaseMult(seed[:])
 	if x == nil {
-		return seed, addr, errors.New("invalid private key")
+		return seed, pub, errors.New("invalid private key")
+	}
+	if g.splitPub != nil {
+		// seed == -s (mod N) would hit the point at infinity; with a random
+		// 256-bit base that is as likely as guessing s outright.
+		x, y = curve.Add(x, y, g.splitPub.X, g.splitPub.Y)
 	}
-	var pub [64]byte
 	ethmath.ReadBits(x, pub[:32])
 	ethmath.ReadBits(y, pub[32:])
-	// Keccak256Hash returns a value-type common.Hash so the output doesn't escape.
-	hash := crypto.Keccak256Hash(pub[:])
-	copy(addr[:], hash[12:32])
-	return seed, addr, nil
+	return seed, pub, nil
+}
+
+// CompressPublicKey turns NextPublicKey's X || Y into the 33-byte SEC1
+// compressed form.
+func CompressPublicKey(pub [64]byte) []byte {
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | pub[63]&1
+	copy(compressed[1:], pub[:32])
+	return compressed
+}
+
+// Hash160 returns ripemd160(sha256(b)), the hash behind P2PKH, P2WPKH and
+// Cosmos addresses.
+func Hash160(b []byte) [20]byte {
+	sha := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	var out [20]byte
+	h.Sum(out[:0])
+	return out
 }
 
 func bytesToWords(b [32]byte) [4]uint64 {
@@ -162,3 +206,53 @@ func geq256(a, b [4]uint64) bool {
 	}
 	return true
 }
+
+// SetSplitKey switches the generator to split-key mode: every candidate is
+// the address of pub + seed·G, and the seed it returns is only a partial key.
+// Whoever holds pub's private key s gets the full key as (s + seed) mod N
+// (CombineSplitKey), so the machine running the search never learns it.
+// Must be called before the search starts.
+func (g *SecpKeyGenerator) SetSplitKey(pub *ecdsa.PublicKey) {
+	g.splitPub = pub
+}
+
+// Checkpoint implements model.ResumableGenerator. The stored seed is the
+// already-reduced base, which newSecpKeyGeneratorFromSeed maps to itself.
+func (g *SecpKeyGenerator) Checkpoint() model.GeneratorCheckpoint {
+	base := wordsToBytes(g.baseWords)
+	return model.GeneratorCheckpoint{
+		Seed:    hex.EncodeToString(base[:]),
+		Counter: g.counter.Load(),
+		Params:  g.checkpointParams(),
+	}
+}
+
+// Restore implements model.ResumableGenerator.
+func (g *SecpKeyGenerator) Restore(cp model.GeneratorCheckpoint) error {
+	if cp.Params != g.checkpointParams() {
+		return fmt.Errorf("checkpoint was taken with a different split key (%s)", cp.Params)
+	}
+	seed, err := decodeCheckpointSeed(cp.Seed)
+	if err != nil {
+		return err
+	}
+	g.baseWords = newSecpKeyGeneratorFromSeed(seed).baseWords
+	g.counter.Store(cp.Counter)
+	return nil
+}
+
+func (g *SecpKeyGenerator) checkpointParams() string {
+	if g.splitPub == nil {
+		return ""
+	}
+	return fmt.Sprintf("split-key=%x", crypto.CompressPubkey(g.splitPub))
+}
+
+func decodeCheckpointSeed(s string) (seed [32]byte, err error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 32 {
+		return seed, fmt.Errorf("invalid checkpoint seed %q", s)
+	}
+	copy(seed[:], b)
+	return seed, nil
+}
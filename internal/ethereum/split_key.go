@@ -0,0 +1,58 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/naiba/nb/model"
+)
+
+// ParsePublicKey decodes a hex secp256k1 public key: 33-byte compressed,
+// 65-byte uncompressed, or 64-byte uncompressed without the 0x04 prefix.
+func ParsePublicKey(s string) (*ecdsa.PublicKey, error) {
+	b := common.FromHex(s)
+	switch len(b) {
+	case 33:
+		return crypto.DecompressPubkey(b)
+	case 64:
+		return crypto.UnmarshalPubkey(append([]byte{0x04}, b...))
+	case 65:
+		return crypto.UnmarshalPubkey(b)
+	default:
+		return nil, fmt.Errorf("public key must be 33, 64 or 65 bytes of hex, got %d bytes", len(b))
+	}
+}
+
+// CombineSplitKey returns (secret + partial) mod N, the private key of the
+// address a split-key search found for secret's public key.
+func CombineSplitKey(secret, partial []byte) ([]byte, error) {
+	if len(secret) != 32 || len(partial) != 32 {
+		return nil, errors.New("private key and partial key must both be 32 bytes")
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(secret), new(big.Int).SetBytes(partial))
+	sum.Mod(sum, curveOrderBigInt)
+	if sum.Sign() == 0 {
+		return nil, errors.New("combined key is zero")
+	}
+	return sum.FillBytes(make([]byte, 32)), nil
+}
+
+// ApplySplitKey puts kg into split-key mode when config.SplitKey is set.
+// It reports whether it did, so callers print the seed as a partial key.
+func ApplySplitKey(config *model.VanityConfig, kg *SecpKeyGenerator) (bool, error) {
+	if config.SplitKey == "" {
+		return false, nil
+	}
+	pub, err := ParsePublicKey(config.SplitKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid --split-key: %w", err)
+	}
+	kg.SetSplitKey(pub)
+	log.Printf("Split-key mode for %s: results carry a partial key only; combine it with your secret using `nb ethereum combine`", crypto.PubkeyToAddress(*pub).Hex())
+	return true, nil
+}
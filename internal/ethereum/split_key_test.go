@@ -0,0 +1,106 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestSplitKey_CombinedKeyOwnsAddress is the whole point of split-key mode:
+// the searcher only sees the public key, yet secret + partial controls the
+// address it found.
+func TestSplitKey_CombinedKeyOwnsAddress(t *testing.T) {
+	secret, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newSecpKeyGeneratorFromSeed([32]byte{1, 2, 3})
+	g.SetSplitKey(&secret.PublicKey)
+
+	for i := 0; i < 5; i++ {
+		partial, addr, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		combined, err := CombineSplitKey(crypto.FromECDSA(secret), partial[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := crypto.ToECDSA(combined)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := crypto.PubkeyToAddress(pk.PublicKey); got != addr {
+			t.Fatalf("combined key address %s != searched address %x", got.Hex(), addr)
+		}
+
+		// The partial key alone must not own the address.
+		alone, _ := crypto.ToECDSA(partial[:])
+		if crypto.PubkeyToAddress(alone.PublicKey) == addr {
+			t.Fatal("partial key alone controls the address")
+		}
+	}
+}
+
+func TestCombineSplitKey_WrapsModN(t *testing.T) {
+	nMinus1 := make([]byte, 32)
+	copy(nMinus1, curveOrderBigInt.Bytes())
+	nMinus1[31]--
+	two := make([]byte, 32)
+	two[31] = 2
+
+	got, err := CombineSplitKey(nMinus1, two)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0000000000000000000000000000000000000000000000000000000000000001"; hex.EncodeToString(got) != want {
+		t.Fatalf("(N-1) + 2 = %x, want %s", got, want)
+	}
+
+	one := make([]byte, 32)
+	one[31] = 1
+	if _, err := CombineSplitKey(nMinus1, one); err == nil {
+		t.Fatal("expected a zero combined key to be rejected")
+	}
+}
+
+func TestParsePublicKey_Formats(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed := crypto.FromECDSAPub(&key.PublicKey)
+	for name, in := range map[string]string{
+		"compressed":         hex.EncodeToString(crypto.CompressPubkey(&key.PublicKey)),
+		"uncompressed":       "0x" + hex.EncodeToString(uncompressed),
+		"uncompressed no 04": hex.EncodeToString(uncompressed[1:]),
+	} {
+		pub, err := ParsePublicKey(in)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if pub.X.Cmp(key.PublicKey.X) != 0 || pub.Y.Cmp(key.PublicKey.Y) != 0 {
+			t.Fatalf("%s: decoded a different key", name)
+		}
+	}
+	if _, err := ParsePublicKey("0x1234"); err == nil {
+		t.Fatal("expected short key to be rejected")
+	}
+}
+
+func TestSecpKeyGenerator_RestoreRejectsOtherSplitKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	g := newSecpKeyGeneratorFromSeed([32]byte{9})
+	g.SetSplitKey(&key.PublicKey)
+	cp := g.Checkpoint()
+
+	if err := newSecpKeyGeneratorFromSeed([32]byte{9}).Restore(cp); err == nil {
+		t.Fatal("expected a split-key checkpoint to be rejected by a plain generator")
+	}
+	other := newSecpKeyGeneratorFromSeed([32]byte{9})
+	other.SetSplitKey(&key.PublicKey)
+	if err := other.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -60,7 +60,7 @@ func (g *EthereumAddressGenerator) Generate() (string, interface{}, error) {
 func VanityAddress(config *model.VanityConfig) error {
 	log.Printf("REMINDER: Ethereum addresses only contain hexadecimal characters (0-9, a-f, A-F)")
 
-	if err := validateHexContains(config.Contains); err != nil {
+	if err := validateHexContains(config.ContainsList()...); err != nil {
 		return err
 	}
 
@@ -69,10 +69,22 @@ func VanityAddress(config *model.VanityConfig) error {
 		log.Printf("MaskValue: 0x%x", config.MaskValue)
 	}
 
+	var passphrase string
+	if config.KeystoreDir != "" {
+		var err error
+		if passphrase, err = config.KeystorePassphrase(); err != nil {
+			return err
+		}
+	}
+
 	generator, err := NewEthereumAddressGenerator()
 	if err != nil {
 		return err
 	}
+	split, err := ApplySplitKey(config, generator.SecpKeyGenerator)
+	if err != nil {
+		return err
+	}
 
 	logSearchEstimate(curveOrderBigInt, config.Threads)
 
@@ -80,21 +92,40 @@ func VanityAddress(config *model.VanityConfig) error {
 	// lowercase prefilter hits.
 	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
 
-	result, err := searcher.Search(context.Background())
-	if err != nil {
-		return err
-	}
-
-	data := result.Data.(*EthereumAddressData)
-	privateKey, err := data.PrivateKey()
-	if err != nil {
-		return err
-	}
-	privateKeyHex := hex.EncodeToString(crypto.FromECDSA(privateKey))
-
-	log.Printf("Address: %s", data.Address())
-	log.Printf("Private Key (hex): %s", privateKeyHex)
-	log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
-
-	return nil
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*EthereumAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		if split {
+			partialKeyHex := hex.EncodeToString(data.seed[:])
+			record.PartialKey = "0x" + partialKeyHex
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.Address())
+				log.Printf("Partial Private Key (hex): 0x%s", partialKeyHex)
+			})
+		}
+		if config.KeystoreDir != "" {
+			path, err := WriteKeystore(config.KeystoreDir, "", data.seed[:], passphrase)
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.Address())
+				log.Printf("Keystore: %s", path)
+			})
+		}
+
+		privateKey, err := data.PrivateKey()
+		if err != nil {
+			return err
+		}
+		privateKeyHex := hex.EncodeToString(crypto.FromECDSA(privateKey))
+
+		record.PrivateKey = "0x" + privateKeyHex
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Private Key (hex): %s", privateKeyHex)
+			log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
+		})
+	})
 }
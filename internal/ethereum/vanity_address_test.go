@@ -290,3 +290,39 @@ func BenchmarkGenerateAndMatchBitmask(b *testing.B) {
 		_ = matcher.Match(addr)
 	}
 }
+
+// TestSecpKeyGenerator_CheckpointRestore proves a restored generator picks up
+// exactly where the checkpointed one left off, even when the seed starts out
+// at or above N-1 (Checkpoint stores the reduced base).
+func TestSecpKeyGenerator_CheckpointRestore(t *testing.T) {
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = 0xff
+	}
+	gen := newEthereumAddressGeneratorFromSeed(seed)
+	for i := 0; i < 50; i++ {
+		if _, _, err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cp := gen.Checkpoint()
+
+	resumed, err := NewEthereumAddressGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		want, _, _ := gen.Generate()
+		got, _, _ := resumed.Generate()
+		if got != want {
+			t.Fatalf("iteration %d after restore: got %s, want %s", i, got, want)
+		}
+	}
+
+	if err := resumed.Restore(model.GeneratorCheckpoint{Seed: "00"}); err == nil {
+		t.Fatal("expected short seed to be rejected")
+	}
+}
@@ -0,0 +1,32 @@
+package ethereum
+
+import "github.com/naiba/nb/model"
+
+// benchDeployer is the deterministic deployment proxy, a stand-in factory
+// for benchmarking CREATE2.
+const benchDeployer = "0x4e59b44847b379578588920ca78fbf26c0b4956c"
+
+func init() {
+	checksum := func(s *model.VanitySearcher) { s.WithChecksum(EIP55Checksum) }
+	validate := func(c *model.VanityConfig) error { return validateHexContains(c.ContainsList()...) }
+	model.RegisterVanityBenchTarget(model.VanityBenchTarget{
+		Name:      "eth",
+		New:       func() (model.AddressGenerator, error) { return NewEthereumAddressGenerator() },
+		Configure: checksum,
+		Validate:  validate,
+	})
+	model.RegisterVanityBenchTarget(model.VanityBenchTarget{
+		Name:      "create1",
+		New:       func() (model.AddressGenerator, error) { return NewCreate1AddressGenerator() },
+		Configure: checksum,
+		Validate:  validate,
+	})
+	model.RegisterVanityBenchTarget(model.VanityBenchTarget{
+		Name: "create2",
+		New: func() (model.AddressGenerator, error) {
+			return NewCreate2AddressGenerator(benchDeployer, "", "0x00", nil)
+		},
+		Configure: checksum,
+		Validate:  validate,
+	})
+}
@@ -3,7 +3,11 @@ package ethereum
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"log"
+	"math"
+	"math/bits"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,6 +18,7 @@ type Create1AddressData struct {
 	seed              [32]byte
 	deployerAddrBytes [20]byte
 	contractAddrBytes [20]byte
+	nonce             uint64
 }
 
 // DeployerAddress returns the EIP-55 checksummed deployer address (0x-prefixed).
@@ -26,6 +31,11 @@ func (d *Create1AddressData) ContractAddress() string {
 	return common.Address(d.contractAddrBytes).Hex()
 }
 
+// Nonce returns the deployer nonce the contract address is created at.
+func (d *Create1AddressData) Nonce() uint64 {
+	return d.nonce
+}
+
 // PrivateKeyBytes returns the 32-byte private key of the deployer.
 func (d *Create1AddressData) PrivateKeyBytes() []byte {
 	return d.seed[:]
@@ -34,8 +44,14 @@ func (d *Create1AddressData) PrivateKeyBytes() []byte {
 // Create1AddressGenerator generates CREATE1 contract addresses (nonce=0).
 // Wraps SecpKeyGenerator with an extra fixed RLP+Keccak step to turn the
 // deployer address into the nonce-0 contract address.
+//
+// With SetMaxNonce, every key also tries nonces 1..maxNonce: one costly
+// scalar multiplication buys maxNonce+1 cheap keccak candidates.
 type Create1AddressGenerator struct {
 	*SecpKeyGenerator
+
+	maxNonce uint64
+	match    func(address string) bool
 }
 
 func NewCreate1AddressGenerator() (*Create1AddressGenerator, error) {
@@ -50,79 +66,223 @@ func newCreate1AddressGeneratorFromSeed(seed [32]byte) *Create1AddressGenerator
 	return &Create1AddressGenerator{SecpKeyGenerator: newSecpKeyGeneratorFromSeed(seed)}
 }
 
+// SetMaxNonce accepts a key when its contract address at any nonce up to
+// maxNonce satisfies match (normally the searcher's Matcher().Match). The
+// generator then returns the first matching nonce's address, or the last
+// one tried.
+func (g *Create1AddressGenerator) SetMaxNonce(maxNonce uint64, match func(address string) bool) {
+	g.maxNonce = maxNonce
+	g.match = match
+}
+
 func (g *Create1AddressGenerator) Generate() (string, interface{}, error) {
 	seed, deployerAddr, err := g.Next()
 	if err != nil {
 		return "", nil, err
 	}
 
-	contractAddr := computeCreate1AddressBytes(deployerAddr)
+	var nonce uint64
+	contractAddr := computeCreate1AddressBytes(deployerAddr, nonce)
 
 	var hexBuf [40]byte
 	hex.Encode(hexBuf[:], contractAddr[:])
-	return string(hexBuf[:]), &Create1AddressData{
+	address := string(hexBuf[:])
+	for g.match != nil && nonce < g.maxNonce && !g.match(address) {
+		nonce++
+		contractAddr = computeCreate1AddressBytes(deployerAddr, nonce)
+		hex.Encode(hexBuf[:], contractAddr[:])
+		address = string(hexBuf[:])
+	}
+	return address, &Create1AddressData{
 		seed:              seed,
 		deployerAddrBytes: deployerAddr,
 		contractAddrBytes: contractAddr,
+		nonce:             nonce,
 	}, nil
 }
 
-// computeCreate1AddressBytes returns keccak256(rlp([deployer, 0]))[12:].
+// computeCreate1AddressBytes returns keccak256(rlp([deployer, nonce]))[12:].
 //
-// The RLP encoding is fixed-shape, so we build it manually to avoid
-// rlp.EncodeToBytes's reflect overhead. Layout (23 bytes):
+// The RLP encoding is fixed-shape apart from the nonce, so we build it
+// manually to avoid rlp.EncodeToBytes's reflect overhead. Layout (23-30 bytes):
 //
-//	0xd6       list header, 22-byte payload (0xc0 + 22)
+//	0xc0+len   list header; the payload is at most 29 bytes, always short form
 //	0x94       string header, 20-byte address (0x80 + 20)
 //	addr[0:20]
-//	0x80       zero nonce encoded as empty string
-func computeCreate1AddressBytes(deployer [20]byte) [20]byte {
-	var rlpBuf [23]byte
-	rlpBuf[0] = 0xd6
+//	nonce      0 as the empty string 0x80, 1..0x7f as that single byte,
+//	           otherwise 0x80+n followed by n big-endian bytes (no leading zeros)
+func computeCreate1AddressBytes(deployer [20]byte, nonce uint64) [20]byte {
+	var rlpBuf [31]byte
 	rlpBuf[1] = 0x94
 	copy(rlpBuf[2:22], deployer[:])
-	rlpBuf[22] = 0x80
+	n := 22
+	switch {
+	case nonce == 0:
+		rlpBuf[n] = 0x80
+		n++
+	case nonce < 0x80:
+		rlpBuf[n] = byte(nonce)
+		n++
+	default:
+		size := (bits.Len64(nonce) + 7) / 8
+		rlpBuf[n] = 0x80 + byte(size)
+		n++
+		for i := size - 1; i >= 0; i-- {
+			rlpBuf[n] = byte(nonce >> (8 * i))
+			n++
+		}
+	}
+	rlpBuf[0] = 0xc0 + byte(n-1)
 
-	hash := crypto.Keccak256Hash(rlpBuf[:])
+	hash := crypto.Keccak256Hash(rlpBuf[:n])
 	var contract [20]byte
 	copy(contract[:], hash[12:32])
 	return contract
 }
 
-func VanityCreate1Address(config *model.VanityConfig) error {
+// VanityCreate1Address searches for a new deployer key whose contract
+// address at some nonce <= maxNonce matches config.
+func VanityCreate1Address(config *model.VanityConfig, maxNonce uint64) error {
 	log.Printf("REMINDER: Ethereum addresses only contain hexadecimal characters (0-9, a-f, A-F)")
-	log.Printf("Searching for contract address (first deployment, nonce=0) containing: %s", config.Contains)
+	if maxNonce > 0 {
+		log.Printf("Searching for contract address (any nonce up to %d) containing: %s", maxNonce, config.Contains)
+	} else {
+		log.Printf("Searching for contract address (first deployment, nonce=0) containing: %s", config.Contains)
+	}
 
-	if err := validateHexContains(config.Contains); err != nil {
+	if err := validateHexContains(config.ContainsList()...); err != nil {
 		return err
 	}
+	if maxNonce > 0 && config.Scorer != nil {
+		return fmt.Errorf("--max-nonce does not apply to --score")
+	}
 
 	if config.Mask != nil {
 		log.Printf("Mask: 0x%x", config.Mask)
 		log.Printf("MaskValue: 0x%x", config.MaskValue)
 	}
 
+	var passphrase string
+	if config.KeystoreDir != "" {
+		var err error
+		if passphrase, err = config.KeystorePassphrase(); err != nil {
+			return err
+		}
+	}
+
 	generator, err := NewCreate1AddressGenerator()
 	if err != nil {
 		return err
 	}
+	split, err := ApplySplitKey(config, generator.SecpKeyGenerator)
+	if err != nil {
+		return err
+	}
 
 	logSearchEstimate(curveOrderBigInt, config.Threads)
 
 	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
-
-	result, err := searcher.Search(context.Background())
-	if err != nil {
-		return err
+	if maxNonce > 0 {
+		generator.SetMaxNonce(maxNonce, searcher.Matcher().Match)
+		// A hit at nonce k costs k burned transactions, so the estimate is
+		// optimistic by the same margin the --max-nonce trade buys.
+		searcher.WithCandidatesPerAttempt(int(min(maxNonce, math.MaxInt32)) + 1)
 	}
 
-	data := result.Data.(*Create1AddressData)
-	privateKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*Create1AddressData)
+		record := model.NewVanityRecord(data.ContractAddress(), result)
+		record.Deployer = data.DeployerAddress()
+		nonce := data.Nonce()
+		record.Nonce = &nonce
+		logContract := func() {
+			log.Printf("Deployer Address: %s", data.DeployerAddress())
+			logCreate1Contract(data.ContractAddress(), nonce)
+		}
+		if split {
+			partialKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
+			record.PartialKey = "0x" + partialKeyHex
+			return model.ReportVanityResult(config, record, func() {
+				logContract()
+				log.Printf("Deployer Partial Private Key (hex): 0x%s", partialKeyHex)
+			})
+		}
+		if config.KeystoreDir != "" {
+			path, err := WriteKeystore(config.KeystoreDir, "", data.PrivateKeyBytes(), passphrase)
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				logContract()
+				log.Printf("Deployer Keystore: %s", path)
+			})
+		}
 
-	log.Printf("Deployer Address: %s", data.DeployerAddress())
-	log.Printf("Contract Address (first deployment, nonce=0): %s", data.ContractAddress())
-	log.Printf("Private Key (hex): %s", privateKeyHex)
-	log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
+		privateKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
+		record.PrivateKey = "0x" + privateKeyHex
+		return model.ReportVanityResult(config, record, func() {
+			logContract()
+			log.Printf("Private Key (hex): %s", privateKeyHex)
+			log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
+		})
+	})
+}
 
+// ScanCreate1Nonces walks nonces 0..maxNonce of an existing deployer and
+// reports the first config.Count whose contract address matches, so the
+// deployer can burn transactions up to that nonce and then deploy.
+func ScanCreate1Nonces(config *model.VanityConfig, deployer string, maxNonce uint64) error {
+	if !common.IsHexAddress(deployer) {
+		return fmt.Errorf("invalid deployer address: %s", deployer)
+	}
+	if err := validateHexContains(config.ContainsList()...); err != nil {
+		return err
+	}
+	switch {
+	case config.Scorer != nil:
+		return fmt.Errorf("--score does not apply to a --deployer scan")
+	case config.SplitKey != "", config.KeystoreDir != "", config.StatePath != "":
+		return fmt.Errorf("--split-key, --keystore and --resume do not apply to a --deployer scan: no key is generated")
+	}
+	deployerAddr := common.HexToAddress(deployer)
+	log.Printf("Scanning nonces 0..%d of %s for contract address containing: %s", maxNonce, deployerAddr.Hex(), config.Contains)
+
+	matcher := model.NewVanityMatcher(config).WithChecksum(EIP55Checksum)
+	start := time.Now()
+	found := 0
+	var hexBuf [40]byte
+	for nonce := uint64(0); ; nonce++ {
+		contractAddr := computeCreate1AddressBytes(deployerAddr, nonce)
+		hex.Encode(hexBuf[:], contractAddr[:])
+		if matcher.Match(string(hexBuf[:])) {
+			contract := common.Address(contractAddr).Hex()
+			record := model.NewVanityRecord(contract, &model.VanityResult{Attempts: nonce + 1, Elapsed: time.Since(start)})
+			record.Deployer = deployerAddr.Hex()
+			record.Nonce = &nonce
+			if err := model.ReportVanityResult(config, record, func() {
+				logCreate1Contract(contract, nonce)
+			}); err != nil {
+				return err
+			}
+			if found++; found >= max(config.Count, 1) {
+				return nil
+			}
+		}
+		if nonce == maxNonce {
+			break
+		}
+	}
+	if found == 0 {
+		return fmt.Errorf("no contract address up to nonce %d matches", maxNonce)
+	}
 	return nil
 }
+
+func logCreate1Contract(contract string, nonce uint64) {
+	if nonce == 0 {
+		log.Printf("Contract Address (first deployment, nonce=0): %s", contract)
+		return
+	}
+	log.Printf("Contract Address (deployed at nonce=%d; send transactions until then): %s", nonce, contract)
+}
@@ -2,13 +2,16 @@ package ethereum
 
 import (
 	"bytes"
+	"encoding/hex"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/naiba/nb/model"
 )
 
 func BenchmarkCreate1GenerateAndMatch(b *testing.B) {
@@ -66,7 +69,7 @@ func TestCreate1AddressGenerator_PrivateKeyValidity(t *testing.T) {
 		}
 
 		// 2. Contract address must match CREATE1 derivation.
-		expectedContract := computeCreate1AddressBytes(d.deployerAddrBytes)
+		expectedContract := computeCreate1AddressBytes(d.deployerAddrBytes, 0)
 		if d.contractAddrBytes != expectedContract {
 			t.Fatalf("contract address mismatch at iteration %d", i)
 		}
@@ -118,7 +121,7 @@ func TestCreate1_ManualRLPMatchesLibrary(t *testing.T) {
 
 		// And the derived address must match too.
 		expected := crypto.Keccak256Hash(ref).Bytes()[12:]
-		got := computeCreate1AddressBytes(a)
+		got := computeCreate1AddressBytes(a, 0)
 		if !bytes.Equal(got[:], expected) {
 			t.Fatalf("derived address mismatch for %x", a)
 		}
@@ -190,3 +193,72 @@ func TestCreate1AddressGenerator_SeedNearCurveOrder(t *testing.T) {
 		}
 	}
 }
+
+// TestCreate1_NonceEncodingMatchesLibrary covers every RLP nonce form: the
+// empty string for 0, single bytes below 0x80, and 1..8 byte big-endian
+// strings, against go-ethereum's CreateAddress.
+func TestCreate1_NonceEncodingMatchesLibrary(t *testing.T) {
+	deployer := common.HexToAddress("0xdeadbeef00112233445566778899aabbccddeeff")
+	nonces := []uint64{0, 1, 0x7f, 0x80, 0xff, 0x100, 0xffff, 0x10000, 1 << 32, 1<<56 + 1, math.MaxUint64}
+	for _, nonce := range nonces {
+		got := computeCreate1AddressBytes(deployer, nonce)
+		if want := crypto.CreateAddress(deployer, nonce); common.Address(got) != want {
+			t.Fatalf("nonce %d: got %x, want %s", nonce, got, want.Hex())
+		}
+	}
+}
+
+func TestCreate1AddressGenerator_MaxNonce(t *testing.T) {
+	const maxNonce = 20
+	gen := newCreate1AddressGeneratorFromSeed([32]byte{0x42})
+	matches := func(address string) bool { return address[0] == '0' }
+	gen.SetMaxNonce(maxNonce, matches)
+
+	hits := 0
+	for i := 0; i < 100; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := data.(*Create1AddressData)
+		if d.contractAddrBytes != computeCreate1AddressBytes(d.deployerAddrBytes, d.Nonce()) {
+			t.Fatalf("contract address does not belong to nonce %d", d.Nonce())
+		}
+		if d.Nonce() > maxNonce {
+			t.Fatalf("nonce %d exceeds max nonce", d.Nonce())
+		}
+		// The generator must return the first matching nonce.
+		for nonce := uint64(0); nonce < d.Nonce(); nonce++ {
+			earlier := computeCreate1AddressBytes(d.deployerAddrBytes, nonce)
+			if matches(hex.EncodeToString(earlier[:])) {
+				t.Fatalf("nonce %d matches before returned nonce %d", nonce, d.Nonce())
+			}
+		}
+		if matches(addr) {
+			hits++
+		}
+	}
+	// P(no hit in 21 tries) = (15/16)^21 ≈ 26%, so most keys must hit.
+	if hits < 50 {
+		t.Fatalf("only %d of 100 keys matched within %d nonces", hits, maxNonce)
+	}
+}
+
+func TestScanCreate1Nonces(t *testing.T) {
+	deployer := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	target := crypto.CreateAddress(deployer, 300)
+	cfg := &model.VanityConfig{
+		Contains: strings.TrimPrefix(strings.ToLower(target.Hex()), "0x")[:8],
+		Mode:     model.VanityModePrefix,
+	}
+
+	if err := ScanCreate1Nonces(cfg, deployer.Hex(), 299); err == nil {
+		t.Fatal("scan below the matching nonce should fail")
+	}
+	if err := ScanCreate1Nonces(cfg, deployer.Hex(), 1000); err != nil {
+		t.Fatalf("scan up to 1000: %v", err)
+	}
+	if err := ScanCreate1Nonces(cfg, "0x1234", 10); err == nil {
+		t.Fatal("invalid deployer should fail")
+	}
+}
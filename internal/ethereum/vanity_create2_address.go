@@ -12,13 +12,15 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/naiba/nb/model"
 )
 
 type Create2AddressData struct {
 	addrBytes  [20]byte
-	saltStr    string
+	salt       [32]byte // as passed to the factory
+	saltStr    string   // keccak scheme only
 	saltSuffix uint64
 }
 
@@ -33,10 +35,16 @@ func (d *Create2AddressData) AddressBytes() [20]byte {
 }
 
 // SaltString returns the salt (pre-hash) string used to produce the address.
+// Empty for the raw salt schemes.
 func (d *Create2AddressData) SaltString() string {
 	return d.saltStr
 }
 
+// Salt returns the bytes32 salt to pass to the factory.
+func (d *Create2AddressData) Salt() [32]byte {
+	return d.salt
+}
+
 // Create2AddressGenerator mines CREATE2 addresses via EIP-1014:
 //
 //	addr = keccak256(0xff || deployer(20) || salt(32) || initCodeHash(32))[12:]
@@ -55,6 +63,11 @@ type Create2AddressGenerator struct {
 	counter    atomic.Uint64
 	saltPrefix string
 
+	// Raw salt schemes (see SetSaltScheme); scheme.Name is SaltSchemeKeccak
+	// for the saltPrefix path.
+	scheme       Create2SaltScheme
+	saltTemplate [32]byte
+
 	// hashInputTemplate layout:
 	//   [0]     0xff
 	//   [1:21]  deployer
@@ -97,7 +110,7 @@ func NewCreate2AddressGenerator(deployer, saltPrefix, contractBin string, constr
 	initCode := append(common.FromHex(contractBin), argsPacked...)
 	initCodeHash := crypto.Keccak256(initCode)
 
-	g := &Create2AddressGenerator{saltPrefix: saltPrefix}
+	g := &Create2AddressGenerator{saltPrefix: saltPrefix, scheme: Create2SaltScheme{Name: SaltSchemeKeccak}}
 	g.hashInputTemplate[0] = 0xff
 	copy(g.hashInputTemplate[1:21], common.HexToAddress(deployer).Bytes())
 	// salt window [21:53] is filled per-call
@@ -105,34 +118,104 @@ func NewCreate2AddressGenerator(deployer, saltPrefix, contractBin string, constr
 	return g, nil
 }
 
+// SetSaltScheme switches from the keccak256(saltPrefix + hex(counter)) salt
+// to one of the raw bytes32 schemes. The deployer must already be the
+// factory that performs the CREATE2 (CreateX for SaltSchemeCreateX).
+func (g *Create2AddressGenerator) SetSaltScheme(scheme Create2SaltScheme) error {
+	if err := scheme.validate(); err != nil {
+		return err
+	}
+	if scheme.Name != SaltSchemeKeccak && g.saltPrefix != "" {
+		return fmt.Errorf("--salt-prefix only applies to salt scheme %s", SaltSchemeKeccak)
+	}
+	g.scheme = scheme
+	g.saltTemplate = scheme.template()
+	return nil
+}
+
+// SetHashPrefix replaces EIP-1014's 0xff marker byte at the start of the
+// hash input. TVM's CREATE2 uses 0x41, Tron's address prefix, instead.
+// Must be called before the search starts.
+func (g *Create2AddressGenerator) SetHashPrefix(prefix byte) {
+	g.hashInputTemplate[0] = prefix
+}
+
 func (g *Create2AddressGenerator) Generate() (string, interface{}, error) {
-	saltSuffix := g.counter.Add(1) - 1
+	data := g.next()
+	var hexBuf [40]byte
+	hex.Encode(hexBuf[:], data.addrBytes[:])
+	return string(hexBuf[:]), data, nil
+}
 
-	// Build "saltPrefix<hex(suffix)>" into a stack buffer, avoiding fmt.Sprintf.
-	// NewCreate2AddressGenerator enforces len(saltPrefix) <= maxSaltPrefixLen,
-	// so prefix + 16 hex chars (max for uint64) always fits in saltBuf.
-	var saltBuf [saltBufLen]byte
-	n := copy(saltBuf[:], g.saltPrefix)
-	n += len(strconv.AppendUint(saltBuf[n:n], saltSuffix, 16))
-	saltStr := string(saltBuf[:n])
+// next derives the next salt and its CREATE2 address; CREATE3 builds on it.
+func (g *Create2AddressGenerator) next() *Create2AddressData {
+	saltSuffix := g.counter.Add(1) - 1
 
-	saltHash := crypto.Keccak256Hash([]byte(saltStr))
+	var salt [32]byte
+	var saltStr string
+	create2Salt := &salt
+	if g.scheme.Name == SaltSchemeKeccak {
+		// Build "saltPrefix<hex(suffix)>" into a stack buffer, avoiding fmt.Sprintf.
+		// NewCreate2AddressGenerator enforces len(saltPrefix) <= maxSaltPrefixLen,
+		// so prefix + 16 hex chars (max for uint64) always fits in saltBuf.
+		var saltBuf [saltBufLen]byte
+		n := copy(saltBuf[:], g.saltPrefix)
+		n += len(strconv.AppendUint(saltBuf[n:n], saltSuffix, 16))
+		saltStr = string(saltBuf[:n])
+		salt = crypto.Keccak256Hash([]byte(saltStr))
+	} else {
+		salt = rawSalt(g.saltTemplate, saltSuffix)
+		if g.scheme.Name == SaltSchemeCreateX {
+			guarded := g.scheme.createXGuard(salt)
+			create2Salt = &guarded
+		}
+	}
 
 	hashInput := g.hashInputTemplate
-	copy(hashInput[21:53], saltHash[:])
+	copy(hashInput[21:53], create2Salt[:])
 	out := crypto.Keccak256Hash(hashInput[:])
 
 	var addr [20]byte
 	copy(addr[:], out[12:32])
 
-	var hexBuf [40]byte
-	hex.Encode(hexBuf[:], addr[:])
-
-	return string(hexBuf[:]), &Create2AddressData{
+	return &Create2AddressData{
 		addrBytes:  addr,
+		salt:       salt,
 		saltStr:    saltStr,
 		saltSuffix: saltSuffix,
-	}, nil
+	}
+}
+
+// Checkpoint implements model.ResumableGenerator. CREATE2 mining is a pure
+// counter walk, so there is no seed; Params pins the deployer, salt prefix and
+// init code hash so a state file can't be resumed against different inputs.
+func (g *Create2AddressGenerator) Checkpoint() model.GeneratorCheckpoint {
+	return model.GeneratorCheckpoint{
+		Counter: g.counter.Load(),
+		Params:  g.checkpointParams(),
+	}
+}
+
+// Restore implements model.ResumableGenerator.
+func (g *Create2AddressGenerator) Restore(cp model.GeneratorCheckpoint) error {
+	if cp.Params != g.checkpointParams() {
+		return fmt.Errorf("checkpoint was taken with different deployer/salt-prefix/init code (%s)", cp.Params)
+	}
+	g.counter.Store(cp.Counter)
+	return nil
+}
+
+func (g *Create2AddressGenerator) checkpointParams() string {
+	params := fmt.Sprintf("deployer=%x salt-prefix=%s init-code-hash=%x",
+		g.hashInputTemplate[1:21], g.saltPrefix, g.hashInputTemplate[53:85])
+	if g.scheme.Name != SaltSchemeKeccak {
+		// Appended only for raw schemes so older state files still resume.
+		params += " salt-scheme=" + g.scheme.String()
+	}
+	if g.hashInputTemplate[0] != 0xff {
+		params += fmt.Sprintf(" hash-prefix=%02x", g.hashInputTemplate[0])
+	}
+	return params
 }
 
 func abiStringArgToInterface(t string, v string) interface{} {
@@ -149,11 +232,35 @@ func abiStringArgToInterface(t string, v string) interface{} {
 	panic(fmt.Sprintf("unsupported type %s", t))
 }
 
-func VanityCreate2Address(config *model.VanityConfig, deployer, saltPrefix, contractBin string, constructorArgs []string) error {
+// resolveFactoryDeployer defaults the deployer to CreateX for its salt scheme
+// and warns when the CreateX guard is applied to some other factory.
+func resolveFactoryDeployer(deployer string, scheme Create2SaltScheme) (string, error) {
+	if deployer == "" {
+		if scheme.Name != SaltSchemeCreateX {
+			return "", fmt.Errorf("--deployer is required (it defaults to CreateX only with --salt-scheme %s)", SaltSchemeCreateX)
+		}
+		return CreateXAddress.Hex(), nil
+	}
+	if scheme.Name == SaltSchemeCreateX && common.HexToAddress(deployer) != CreateXAddress {
+		log.Printf("WARNING: deployer %s is not CreateX (%s); the CreateX salt guard is applied anyway", deployer, CreateXAddress.Hex())
+	}
+	return deployer, nil
+}
+
+func VanityCreate2Address(config *model.VanityConfig, deployer, saltPrefix string, scheme Create2SaltScheme, contractBin string, constructorArgs []string) error {
+	deployer, err := resolveFactoryDeployer(deployer, scheme)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("REMINDER: Ethereum addresses only contain hexadecimal characters (0-9, a-f, A-F)")
 	log.Printf("Searching for CREATE2 address with deployer: %s", deployer)
 
-	if err := validateHexContains(config.Contains); err != nil {
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to CREATE2: the search yields a salt, not a private key")
+	}
+
+	if err := validateHexContains(config.ContainsList()...); err != nil {
 		return err
 	}
 
@@ -166,20 +273,36 @@ func VanityCreate2Address(config *model.VanityConfig, deployer, saltPrefix, cont
 	if err != nil {
 		return err
 	}
-
-	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
-
-	result, err := searcher.Search(context.Background())
-	if err != nil {
+	if err := generator.SetSaltScheme(scheme); err != nil {
 		return err
 	}
+	log.Printf("Salt scheme: %s", generator.scheme.String())
 
-	data := result.Data.(*Create2AddressData)
-	salt := crypto.Keccak256([]byte(data.saltStr))
+	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
 
-	log.Printf("Address: %s", data.Address())
-	log.Printf("Salt: %s", data.saltStr)
-	log.Printf("Salt (keccak256): 0x%x", salt)
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*Create2AddressData)
+
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Deployer = common.HexToAddress(deployer).Hex()
+		record.Salt = hexutil.Encode(data.salt[:])
+		record.SaltPreimage = data.saltStr
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			logCreate2Salt(data)
+			if config.Target > 0 {
+				logZeroBytes(data.addrBytes)
+			}
+		})
+	})
+}
 
-	return nil
+// logCreate2Salt logs the factory salt, with its preimage for the keccak scheme.
+func logCreate2Salt(data *Create2AddressData) {
+	if data.saltStr != "" {
+		log.Printf("Salt: %s", data.saltStr)
+		log.Printf("Salt (keccak256): 0x%x", data.salt)
+	} else {
+		log.Printf("Salt (bytes32): 0x%x", data.salt)
+	}
 }
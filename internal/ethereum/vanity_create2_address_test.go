@@ -1,8 +1,11 @@
 package ethereum
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -106,3 +109,110 @@ func TestCreate2AddressGenerator_SaltPrefixed(t *testing.T) {
 		}
 	}
 }
+
+func TestCreate2AddressGenerator_CheckpointRestore(t *testing.T) {
+	gen := newCreate2BenchGenerator(t)
+	for i := 0; i < 10; i++ {
+		gen.Generate()
+	}
+	cp := gen.Checkpoint()
+
+	resumed := newCreate2BenchGenerator(t)
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	want, _, _ := gen.Generate()
+	got, _, _ := resumed.Generate()
+	if got != want {
+		t.Fatalf("after restore: got %s, want %s", got, want)
+	}
+
+	other, err := NewCreate2AddressGenerator("0x4e59b44847b379578588920ca78fbf26c0b4956c", "other", "0x00", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Restore(cp); err == nil {
+		t.Fatal("expected restore with a different salt prefix to fail")
+	}
+}
+
+func TestCreate2AddressGenerator_RawSaltSchemes(t *testing.T) {
+	me := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tests := []struct {
+		scheme Create2SaltScheme
+		top    []byte // expected salt[:21]
+	}{
+		{Create2SaltScheme{Name: SaltSchemeCounter}, make([]byte, 21)},
+		{Create2SaltScheme{Name: SaltSchemeAddress, Address: me}, append(me.Bytes(), 0)},
+		{Create2SaltScheme{Name: SaltSchemeCreateX, Address: me}, append(me.Bytes(), 0)},
+		{Create2SaltScheme{Name: SaltSchemeCreateX, Address: me, ChainID: 1}, append(me.Bytes(), 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme.String(), func(t *testing.T) {
+			gen := newCreate2BenchGenerator(t)
+			if err := gen.SetSaltScheme(tt.scheme); err != nil {
+				t.Fatal(err)
+			}
+			deployer := common.BytesToAddress(gen.hashInputTemplate[1:21])
+			initCodeHash := gen.hashInputTemplate[53:85]
+			for i := 0; i < 3; i++ {
+				_, data, err := gen.Generate()
+				if err != nil {
+					t.Fatal(err)
+				}
+				d := data.(*Create2AddressData)
+				salt := d.Salt()
+				if !bytes.Equal(salt[:21], tt.top) {
+					t.Fatalf("salt %x: top bytes want %x", salt, tt.top)
+				}
+				if got := new(big.Int).SetBytes(salt[21:]).Uint64(); got != uint64(i) {
+					t.Fatalf("salt %x: counter = %d, want %d", salt, got, i)
+				}
+				create2Salt := salt
+				if tt.scheme.Name == SaltSchemeCreateX {
+					create2Salt = tt.scheme.createXGuard(salt)
+				}
+				if want := crypto.CreateAddress2(deployer, create2Salt, initCodeHash); common.Address(d.AddressBytes()) != want {
+					t.Fatalf("address %x, want %s", d.AddressBytes(), want.Hex())
+				}
+			}
+		})
+	}
+}
+
+// TestCreateXGuard_MatchesABIEncoding checks the permissioned + cross-chain
+// case against abi.encode(msg.sender, block.chainid, salt).
+func TestCreateXGuard_MatchesABIEncoding(t *testing.T) {
+	scheme := Create2SaltScheme{Name: SaltSchemeCreateX, Address: common.HexToAddress("0x2222222222222222222222222222222222222222"), ChainID: 8453}
+	salt := rawSalt(scheme.template(), 42)
+
+	addressT, _ := abi.NewType("address", "", nil)
+	uintT, _ := abi.NewType("uint256", "", nil)
+	bytes32T, _ := abi.NewType("bytes32", "", nil)
+	encoded, err := abi.Arguments{{Type: addressT}, {Type: uintT}, {Type: bytes32T}}.Pack(scheme.Address, new(big.Int).SetUint64(scheme.ChainID), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := scheme.createXGuard(salt), crypto.Keccak256Hash(encoded); got != want {
+		t.Fatalf("guarded salt %x, want %x", got, want)
+	}
+}
+
+func TestCreate2AddressGenerator_SaltSchemeValidation(t *testing.T) {
+	gen, err := NewCreate2AddressGenerator("0x4e59b44847b379578588920ca78fbf26c0b4956c", "p", "0x00", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.SetSaltScheme(Create2SaltScheme{Name: SaltSchemeCounter}); err == nil {
+		t.Error("expected --salt-prefix to be rejected with a raw scheme")
+	}
+	for _, scheme := range []Create2SaltScheme{
+		{Name: "nope"},
+		{Name: SaltSchemeAddress},
+		{Name: SaltSchemeCounter, ChainID: 1},
+	} {
+		if err := newCreate2BenchGenerator(t).SetSaltScheme(scheme); err == nil {
+			t.Errorf("SetSaltScheme(%+v) succeeded, want error", scheme)
+		}
+	}
+}
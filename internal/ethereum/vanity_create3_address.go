@@ -0,0 +1,119 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/naiba/nb/model"
+)
+
+// create3ProxyBytecode is the minimal proxy that CREATE3 factories (Solady's
+// CREATE3, CreateX) deploy via CREATE2; it CREATEs whatever init code it is
+// called with. Its keccak256 is the well-known
+// 0x21c35dbe1b344a2488cf3321d6ce542f8e9f305544ff09e4993a62319a497c1f.
+const create3ProxyBytecode = "0x67363d3d37363d34f03d5260086018f3"
+
+type Create3AddressData struct {
+	*Create2AddressData // the proxy's CREATE2 derivation, including the salt
+	contractAddrBytes   [20]byte
+}
+
+// ProxyAddress returns the EIP-55 checksummed address of the CREATE3 proxy.
+func (d *Create3AddressData) ProxyAddress() string {
+	return d.Create2AddressData.Address()
+}
+
+// Address returns the EIP-55 checksummed address of the deployed contract.
+func (d *Create3AddressData) Address() string {
+	return common.Address(d.contractAddrBytes).Hex()
+}
+
+// Create3AddressGenerator mines CREATE3 addresses: the proxy lands at
+// CREATE2(deployer, salt, keccak256(proxy bytecode)) and then CREATEs the
+// contract at nonce 1, so the result is independent of the contract's init
+// code.
+type Create3AddressGenerator struct {
+	*Create2AddressGenerator
+}
+
+func NewCreate3AddressGenerator(deployer, saltPrefix string) (*Create3AddressGenerator, error) {
+	g, err := NewCreate2AddressGenerator(deployer, saltPrefix, create3ProxyBytecode, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Create3AddressGenerator{Create2AddressGenerator: g}, nil
+}
+
+func (g *Create3AddressGenerator) Generate() (string, interface{}, error) {
+	proxy := g.next()
+	// The proxy is a contract, so its first CREATE runs at nonce 1 (EIP-161).
+	contract := computeCreate1AddressBytes(proxy.addrBytes, 1)
+
+	var hexBuf [40]byte
+	hex.Encode(hexBuf[:], contract[:])
+	return string(hexBuf[:]), &Create3AddressData{
+		Create2AddressData: proxy,
+		contractAddrBytes:  contract,
+	}, nil
+}
+
+func VanityCreate3Address(config *model.VanityConfig, deployer, saltPrefix string, scheme Create2SaltScheme) error {
+	deployer, err := resolveFactoryDeployer(deployer, scheme)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("REMINDER: Ethereum addresses only contain hexadecimal characters (0-9, a-f, A-F)")
+	log.Printf("Searching for CREATE3 address with deployer: %s", deployer)
+	if scheme.Name != SaltSchemeCreateX {
+		// CreateX hashes such salts in its guard, and factories like the
+		// 0xSequence/ZeframLou CREATE3Factory hash msg.sender into them.
+		log.Printf("WARNING: salt scheme %s assumes %s hands the salt to CREATE2 unchanged; most CREATE3 factories don't, and would deploy elsewhere. Use --salt-scheme %s with CreateX, or check the address with the factory's getDeployed before deploying", scheme.Name, deployer, SaltSchemeCreateX)
+	}
+
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to CREATE3: the search yields a salt, not a private key")
+	}
+
+	if err := validateHexContains(config.ContainsList()...); err != nil {
+		return err
+	}
+
+	if config.Mask != nil {
+		log.Printf("Mask: 0x%x", config.Mask)
+		log.Printf("MaskValue: 0x%x", config.MaskValue)
+	}
+
+	generator, err := NewCreate3AddressGenerator(deployer, saltPrefix)
+	if err != nil {
+		return err
+	}
+	if err := generator.SetSaltScheme(scheme); err != nil {
+		return err
+	}
+	log.Printf("Salt scheme: %s", generator.scheme.String())
+
+	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*Create3AddressData)
+
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Deployer = common.HexToAddress(deployer).Hex()
+		record.Proxy = data.ProxyAddress()
+		record.Salt = hexutil.Encode(data.salt[:])
+		record.SaltPreimage = data.saltStr
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Proxy Address: %s", data.ProxyAddress())
+			logCreate2Salt(data.Create2AddressData)
+			if config.Target > 0 {
+				logZeroBytes(data.contractAddrBytes)
+			}
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCreate3ProxyBytecodeHash(t *testing.T) {
+	want := common.HexToHash("0x21c35dbe1b344a2488cf3321d6ce542f8e9f305544ff09e4993a62319a497c1f")
+	if got := crypto.Keccak256Hash(common.FromHex(create3ProxyBytecode)); got != want {
+		t.Fatalf("proxy bytecode hash = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+// TestCreate3AddressGenerator_DerivationCorrect re-derives each address with
+// go-ethereum: proxy = CREATE2(deployer, salt, proxyHash), contract =
+// CREATE(proxy, nonce 1).
+func TestCreate3AddressGenerator_DerivationCorrect(t *testing.T) {
+	for _, scheme := range []Create2SaltScheme{
+		{Name: SaltSchemeKeccak},
+		{Name: SaltSchemeCreateX, Address: common.HexToAddress("0x3333333333333333333333333333333333333333"), ChainID: 10},
+	} {
+		gen, err := NewCreate3AddressGenerator(CreateXAddress.Hex(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.SetSaltScheme(scheme); err != nil {
+			t.Fatal(err)
+		}
+		proxyHash := crypto.Keccak256(common.FromHex(create3ProxyBytecode))
+		for i := 0; i < 100; i++ {
+			addrHex, data, err := gen.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := data.(*Create3AddressData)
+			salt := d.Salt()
+			if scheme.Name == SaltSchemeCreateX {
+				salt = scheme.createXGuard(salt)
+			}
+			proxy := crypto.CreateAddress2(CreateXAddress, salt, proxyHash)
+			if d.ProxyAddress() != proxy.Hex() {
+				t.Fatalf("%s iter %d: proxy %s, want %s", scheme.Name, i, d.ProxyAddress(), proxy.Hex())
+			}
+			want := crypto.CreateAddress(proxy, 1)
+			if d.Address() != want.Hex() || common.HexToAddress(addrHex) != want {
+				t.Fatalf("%s iter %d: address %s, want %s", scheme.Name, i, d.Address(), want.Hex())
+			}
+		}
+	}
+}
@@ -10,16 +10,34 @@ import (
 
 // validateHexContains rejects any character outside the Ethereum hex alphabet.
 // Empty input is accepted (callers may bit-mask instead of prefix-match).
-func validateHexContains(s string) error {
+func validateHexContains(patterns ...string) error {
 	const validHexChars = "0123456789abcdefABCDEF"
-	for _, c := range s {
-		if !strings.ContainsRune(validHexChars, c) {
-			return fmt.Errorf("contains illegal character: %c (Ethereum addresses only contain 0-9, a-f, A-F)", c)
+	for _, s := range patterns {
+		for _, c := range s {
+			if !strings.ContainsRune(validHexChars, c) {
+				return fmt.Errorf("contains illegal character: %c (Ethereum addresses only contain 0-9, a-f, A-F)", c)
+			}
 		}
 	}
 	return nil
 }
 
+// logZeroBytes reports how gas-efficient an address is: every zero byte costs
+// 4 instead of 16 gas each time the address appears in calldata.
+func logZeroBytes(addr [20]byte) {
+	leading, total := 0, 0
+	for i, b := range addr {
+		if b != 0 {
+			continue
+		}
+		total++
+		if i == leading {
+			leading++
+		}
+	}
+	log.Printf("Zero bytes: %d leading, %d total (saves %d gas per calldata use)", leading, total, total*12)
+}
+
 // logSearchEstimate logs a rough upper-bound search time for a keyspace of
 // `space` addresses with `threads` workers, capped at 100 years.
 func logSearchEstimate(space *big.Int, threads int) {
@@ -0,0 +1,119 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/naiba/nb/internal/hdwallet"
+	"github.com/naiba/nb/model"
+)
+
+// MnemonicPath is the first account of MetaMask, Ledger Live and most other
+// wallets.
+const MnemonicPath = "m/44'/60'/0'/0/0"
+
+type MnemonicAddressData struct {
+	mnemonic  string
+	key       [32]byte
+	addrBytes [20]byte
+}
+
+// Address returns the EIP-55 checksummed address.
+func (d *MnemonicAddressData) Address() string {
+	return common.Address(d.addrBytes).Hex()
+}
+
+// AddressBytes returns the raw 20-byte address.
+func (d *MnemonicAddressData) AddressBytes() [20]byte {
+	return d.addrBytes
+}
+
+// Mnemonic returns the BIP-39 phrase.
+func (d *MnemonicAddressData) Mnemonic() string {
+	return d.mnemonic
+}
+
+// MnemonicAddressGenerator derives the secp256k1 account at path of each
+// mnemonic. Tron reuses it with its own coin type.
+type MnemonicAddressGenerator struct {
+	*hdwallet.MnemonicGenerator
+	path []uint32
+}
+
+func NewMnemonicAddressGenerator(words int, path string) (*MnemonicAddressGenerator, error) {
+	indexes, err := hdwallet.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	mg, err := hdwallet.NewMnemonicGenerator(words)
+	if err != nil {
+		return nil, err
+	}
+	return &MnemonicAddressGenerator{MnemonicGenerator: mg, path: indexes}, nil
+}
+
+func (g *MnemonicAddressGenerator) Generate() (string, interface{}, error) {
+	mnemonic, seed, err := g.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	key, err := hdwallet.DeriveSecp256k1(seed, g.path)
+	if err != nil {
+		return "", nil, err
+	}
+	privateKey, err := crypto.ToECDSA(key[:])
+	if err != nil {
+		return "", nil, err
+	}
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	return hex.EncodeToString(addr[:]), &MnemonicAddressData{
+		mnemonic:  mnemonic,
+		key:       key,
+		addrBytes: addr,
+	}, nil
+}
+
+// VanityMnemonicAddress searches mnemonics whose MnemonicPath account
+// matches config.
+func VanityMnemonicAddress(config *model.VanityConfig, words int) error {
+	log.Printf("REMINDER: Ethereum addresses only contain hexadecimal characters (0-9, a-f, A-F)")
+
+	if err := validateHexContains(config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to --mnemonic: the phrase is printed, store it yourself")
+	}
+	if config.SplitKey != "" {
+		return fmt.Errorf("--split-key does not apply to --mnemonic")
+	}
+	if config.Mask != nil {
+		log.Printf("Mask: 0x%x", config.Mask)
+		log.Printf("MaskValue: 0x%x", config.MaskValue)
+	}
+
+	generator, err := NewMnemonicAddressGenerator(words, MnemonicPath)
+	if err != nil {
+		return err
+	}
+	log.Printf("Searching %d-word mnemonics, account %s", words, MnemonicPath)
+
+	searcher := model.NewVanitySearcher(config, generator).WithChecksum(EIP55Checksum)
+	searcher.LogEstimate(hdwallet.AttemptsPerSecond)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*MnemonicAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Path = MnemonicPath
+		record.Mnemonic = data.Mnemonic()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Mnemonic: %s", data.Mnemonic())
+			log.Printf("Path: %s", MnemonicPath)
+		})
+	})
+}
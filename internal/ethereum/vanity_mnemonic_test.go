@@ -0,0 +1,39 @@
+package ethereum
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/naiba/nb/internal/hdwallet"
+	"github.com/naiba/nb/model"
+)
+
+// TestMnemonicAddressGenerator_KnownVector checks the all-zero entropy
+// mnemonic ("abandon ... about") against the address MetaMask shows for it.
+func TestMnemonicAddressGenerator_KnownVector(t *testing.T) {
+	gen, err := NewMnemonicAddressGenerator(12, MnemonicPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gen.MnemonicGenerator, err = hdwallet.NewMnemonicGeneratorFromEntropy(make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	}
+	addr, data, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := data.(*MnemonicAddressData)
+	if want := "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"; d.Address() != want {
+		t.Fatalf("got %s, want %s", d.Address(), want)
+	}
+	if addr != "9858effd232b4033e47d90003d41ec34ecaeda94" {
+		t.Fatalf("matcher form %s is not lowercase hex without 0x", addr)
+	}
+}
+
+func TestVanityMnemonicAddress_RejectsKeystore(t *testing.T) {
+	config := &model.VanityConfig{Contains: "cafe", KeystoreDir: t.TempDir()}
+	if err := VanityMnemonicAddress(config, 12); err == nil || !strings.Contains(err.Error(), "--keystore") {
+		t.Errorf("err = %v, want --keystore rejected", err)
+	}
+}
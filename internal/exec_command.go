@@ -18,6 +18,22 @@ func ExecuteInHostWithOutput(env []string, name string, args ...string) ([]byte,
 	return cmd.Output()
 }
 
+// ExecuteArgsInHostWithOutput is ExecuteInHostWithOutput without bash: args
+// reach the command as-is, however they are quoted.
+func ExecuteArgsInHostWithOutput(env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Output()
+}
+
+// ExecuteArgsInHostWithCombinedOutput is ExecuteArgsInHostWithOutput with
+// stderr interleaved into the output.
+func ExecuteArgsInHostWithCombinedOutput(env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.CombinedOutput()
+}
+
 func BashScriptExecuteInHost(line string) error {
 	command := BuildCommand(nil, "bash", "-c", line)
 	return command.Run()
@@ -0,0 +1,117 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Test vector 1 of BIP-32 and SLIP-10 shares this seed.
+var vectorSeed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestDeriveSecp256k1_BIP32Vector1(t *testing.T) {
+	cases := map[string]string{
+		"m/0'":        "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":      "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0'/1/2'/2": "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+	}
+	for path, want := range cases {
+		key, err := DeriveSecp256k1(vectorSeed, MustParsePath(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(key[:]); got != want {
+			t.Fatalf("%s: got %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestDeriveEd25519_SLIP10Vector1(t *testing.T) {
+	cases := map[string]string{
+		"m":             "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"m/0'":          "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"m/0'/1'/2'/2'": "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+	}
+	for path, want := range cases {
+		key, err := DeriveEd25519(vectorSeed, MustParsePath(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(key.Seed()); got != want {
+			t.Fatalf("%s: got %s, want %s", path, got, want)
+		}
+	}
+	if _, err := DeriveEd25519(vectorSeed, MustParsePath("m/0'/1")); err == nil {
+		t.Fatal("non-hardened ed25519 derivation accepted")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	got, err := ParsePath("m/44'/60'/0'/0/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{44 + Hardened, 60 + Hardened, Hardened, 0, 7}
+	for i := range want {
+		if len(got) != len(want) || got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	for _, bad := range []string{"44'/60'", "m/x", "m/2147483648", "m/-1"} {
+		if _, err := ParsePath(bad); err == nil {
+			t.Fatalf("ParsePath(%q) accepted", bad)
+		}
+	}
+}
+
+func TestMnemonicGenerator_CounterWalk(t *testing.T) {
+	entropy := make([]byte, 16)
+	for i := range entropy {
+		entropy[i] = 0xff
+	}
+	gen, err := NewMnemonicGeneratorFromEntropy(entropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, seed, err := gen.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, "zoo zoo") || len(strings.Fields(first)) != 12 {
+		t.Fatalf("unexpected first mnemonic %q", first)
+	}
+	if want := bip39.NewSeed(first, ""); hex.EncodeToString(seed) != hex.EncodeToString(want) {
+		t.Fatal("seed doesn't match bip39.NewSeed")
+	}
+	// The counter carries through every byte and wraps to all zeros.
+	second, _, _ := gen.Next()
+	if want := strings.TrimSuffix(strings.Repeat("abandon ", 11), " ") + " about"; second != want {
+		t.Fatalf("after wrap: got %q, want %q", second, want)
+	}
+}
+
+func TestMnemonicGenerator_CheckpointRestore(t *testing.T) {
+	gen, err := NewMnemonicGenerator(24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen.Next()
+	cp := gen.Checkpoint()
+
+	resumed, _ := NewMnemonicGenerator(24)
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	want, _, _ := gen.Next()
+	got, _, _ := resumed.Next()
+	if got != want {
+		t.Fatalf("after restore: got %q, want %q", got, want)
+	}
+
+	short, _ := NewMnemonicGenerator(12)
+	if err := short.Restore(cp); err == nil {
+		t.Fatal("12-word generator restored a 24-word checkpoint")
+	}
+}
@@ -0,0 +1,39 @@
+package internal
+
+import "sync"
+
+var interruptHooks = struct {
+	sync.Mutex
+	next int
+	fns  map[int]func()
+}{fns: make(map[int]func())}
+
+// OnInterrupt registers fn to run when nb receives SIGINT/SIGTERM, before
+// child processes are cleaned up and the process exits. Call the returned
+// func to unregister once the work it guards has finished.
+func OnInterrupt(fn func()) (unregister func()) {
+	interruptHooks.Lock()
+	defer interruptHooks.Unlock()
+	id := interruptHooks.next
+	interruptHooks.next++
+	interruptHooks.fns[id] = fn
+	return func() {
+		interruptHooks.Lock()
+		defer interruptHooks.Unlock()
+		delete(interruptHooks.fns, id)
+	}
+}
+
+// RunInterruptHooks runs every registered hook synchronously. Called from the
+// signal handler in main.
+func RunInterruptHooks() {
+	interruptHooks.Lock()
+	fns := make([]func(), 0, len(interruptHooks.fns))
+	for _, fn := range interruptHooks.fns {
+		fns = append(fns, fn)
+	}
+	interruptHooks.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
@@ -3,18 +3,15 @@ package solana
 import (
 	"context"
 	"crypto/ed25519"
-	"crypto/rand"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
-	"math/bits"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/mr-tron/base58"
+	"github.com/naiba/nb/internal/ed25519keys"
 	"github.com/naiba/nb/model"
 )
 
@@ -23,49 +20,25 @@ type SolanaAddressData struct {
 	privateKey ed25519.PrivateKey
 }
 
-// SolanaAddressGenerator produces ed25519 keys from (baseSeed + counter) mod
-// 2^256. ed25519 accepts any 32-byte seed, so no modular reduction is needed.
+// SolanaAddressGenerator encodes the ed25519 public key itself in base58.
 type SolanaAddressGenerator struct {
-	counter   atomic.Uint64
-	baseWords [4]uint64
+	*ed25519keys.KeyGenerator
 }
 
 func NewSolanaAddressGenerator() (*SolanaAddressGenerator, error) {
-	var baseSeed [32]byte
-	l, err := rand.Read(baseSeed[:])
-	if err != nil || l != 32 {
-		return nil, fmt.Errorf("failed to generate random seed: %v", err)
+	kg, err := ed25519keys.NewKeyGenerator()
+	if err != nil {
+		return nil, err
 	}
-	return newSolanaAddressGeneratorFromSeed(baseSeed), nil
+	return &SolanaAddressGenerator{KeyGenerator: kg}, nil
 }
 
 func newSolanaAddressGeneratorFromSeed(seed [32]byte) *SolanaAddressGenerator {
-	return &SolanaAddressGenerator{baseWords: [4]uint64{
-		binary.BigEndian.Uint64(seed[0:8]),
-		binary.BigEndian.Uint64(seed[8:16]),
-		binary.BigEndian.Uint64(seed[16:24]),
-		binary.BigEndian.Uint64(seed[24:32]),
-	}}
+	return &SolanaAddressGenerator{KeyGenerator: ed25519keys.NewKeyGeneratorFromSeed(seed)}
 }
 
 func (g *SolanaAddressGenerator) Generate() (string, interface{}, error) {
-	counter := g.counter.Add(1) - 1
-
-	// 256-bit add with carry; wrap at 2^256 is fine for ed25519 seeds.
-	var r [4]uint64
-	var carry uint64
-	r[3], carry = bits.Add64(g.baseWords[3], counter, 0)
-	r[2], carry = bits.Add64(g.baseWords[2], 0, carry)
-	r[1], carry = bits.Add64(g.baseWords[1], 0, carry)
-	r[0], _ = bits.Add64(g.baseWords[0], 0, carry)
-
-	var seed [32]byte
-	binary.BigEndian.PutUint64(seed[0:8], r[0])
-	binary.BigEndian.PutUint64(seed[8:16], r[1])
-	binary.BigEndian.PutUint64(seed[16:24], r[2])
-	binary.BigEndian.PutUint64(seed[24:32], r[3])
-
-	privateKey := ed25519.NewKeyFromSeed(seed[:])
+	privateKey := g.Next()
 	address := base58.Encode(privateKey[32:])
 
 	return address, &SolanaAddressData{
@@ -74,17 +47,26 @@ func (g *SolanaAddressGenerator) Generate() (string, interface{}, error) {
 	}, nil
 }
 
-func VanityAddress(config *model.VanityConfig) error {
-	log.Printf("REMINDER: Solana addresses use Base58 encoding (excludes 0, O, I, l)")
-
+// validateBase58Contains rejects any character outside the base58 alphabet.
+func validateBase58Contains(patterns ...string) error {
 	// Base58 alphabet: 123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz
 	// Excluded: 0 (zero), O (capital o), I (capital i), l (lowercase L)
-	validBase58Chars := "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-	for _, char := range config.Contains {
-		if !strings.ContainsRune(validBase58Chars, char) {
-			return fmt.Errorf("contains illegal character: %c (Solana addresses use Base58: excludes 0, O, I, l)", char)
+	for _, pattern := range patterns {
+		for _, char := range pattern {
+			if !strings.ContainsRune(model.Base58Alphabet, char) {
+				return fmt.Errorf("contains illegal character: %c (Solana addresses use Base58: excludes 0, O, I, l)", char)
+			}
 		}
 	}
+	return nil
+}
+
+func VanityAddress(config *model.VanityConfig) error {
+	log.Printf("REMINDER: Solana addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateBase58Contains(config.ContainsList()...); err != nil {
+		return err
+	}
 
 	generator, err := NewSolanaAddressGenerator()
 	if err != nil {
@@ -100,22 +82,35 @@ func VanityAddress(config *model.VanityConfig) error {
 	estimateTime := time.Duration(estimateSeconds.Uint64()) * time.Second
 	log.Printf("Search space: %v addresses, estimated max time: %v (2.6 GHz 6-Core Intel Core i7)", maxUint256, estimateTime)
 
-	searcher := model.NewVanitySearcher(config, generator)
-
-	result, err := searcher.Search(context.Background())
-	if err != nil {
-		return err
-	}
-
-	data := result.Data.(*SolanaAddressData)
-
-	var privateKeyArray [64]byte
-	copy(privateKeyArray[:], data.privateKey)
-	privateKeyJSON, _ := json.Marshal(privateKeyArray)
-
-	log.Printf("Address: %s", data.address)
-	log.Printf("Private Key (bytes): %s", string(privateKeyJSON))
-	log.Printf("Private Key (hex): %x", privateKeyArray)
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*SolanaAddressData)
+
+		var privateKeyArray [64]byte
+		copy(privateKeyArray[:], data.privateKey)
+		privateKeyJSON, _ := json.Marshal(privateKeyArray)
+
+		record := model.NewVanityRecord(data.address, result)
+		if config.KeystoreDir != "" {
+			// solana-keygen keypair format: a JSON array of the 64 secret key bytes.
+			path, err := model.WriteSecretFile(config.KeystoreDir, data.address+".json", privateKeyJSON)
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.address)
+				log.Printf("Keypair File: %s", path)
+			})
+		}
 
-	return nil
+		// base58 of the 64-byte keypair is what wallets (Phantom etc.) import.
+		record.PrivateKey = base58.Encode(privateKeyArray[:])
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.address)
+			log.Printf("Private Key (bytes): %s", string(privateKeyJSON))
+			log.Printf("Private Key (hex): %x", privateKeyArray)
+		})
+	})
 }
@@ -99,7 +99,9 @@ func TestSolanaAddressGenerator_Deterministic(t *testing.T) {
 func TestSolanaAddressGenerator_CounterOverflowBoundary(t *testing.T) {
 	seed := [32]byte{0x01, 0x02, 0x03}
 	gen := newSolanaAddressGeneratorFromSeed(seed)
-	gen.counter.Store(math.MaxInt64 - 1)
+	if err := gen.Restore(model.GeneratorCheckpoint{Seed: gen.Checkpoint().Seed, Counter: math.MaxInt64 - 1}); err != nil {
+		t.Fatal(err)
+	}
 
 	for i := 0; i < 5; i++ {
 		addr, data, err := gen.Generate()
@@ -144,3 +146,26 @@ func TestSolanaAddressGenerator_MaxSeed(t *testing.T) {
 		}
 	}
 }
+
+func TestSolanaAddressGenerator_CheckpointRestore(t *testing.T) {
+	gen := newSolanaAddressGeneratorFromSeed([32]byte{0x42})
+	for i := 0; i < 20; i++ {
+		gen.Generate()
+	}
+	cp := gen.Checkpoint()
+
+	resumed, err := NewSolanaAddressGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		want, _, _ := gen.Generate()
+		got, _, _ := resumed.Generate()
+		if got != want {
+			t.Fatalf("iteration %d after restore: got %s, want %s", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package solana
+
+import "github.com/naiba/nb/model"
+
+func init() {
+	model.RegisterVanityBenchTarget(model.VanityBenchTarget{
+		Name:      "solana",
+		New:       func() (model.AddressGenerator, error) { return NewSolanaAddressGenerator() },
+		Configure: func(s *model.VanitySearcher) { s.WithAlphabet(model.Base58Alphabet) },
+		Validate:  func(c *model.VanityConfig) error { return validateBase58Contains(c.ContainsList()...) },
+	})
+}
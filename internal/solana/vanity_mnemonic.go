@@ -0,0 +1,97 @@
+package solana
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log"
+
+	"github.com/mr-tron/base58"
+	"github.com/naiba/nb/internal/hdwallet"
+	"github.com/naiba/nb/model"
+)
+
+// MnemonicPath is the first account of Phantom, Solflare and
+// `solana-keygen recover 'prompt://?key=0/0'`.
+const MnemonicPath = "m/44'/501'/0'/0'"
+
+var mnemonicPath = hdwallet.MustParsePath(MnemonicPath)
+
+type MnemonicAddressData struct {
+	address  string
+	mnemonic string
+}
+
+// Address returns the base58 public key.
+func (d *MnemonicAddressData) Address() string {
+	return d.address
+}
+
+// Mnemonic returns the BIP-39 phrase.
+func (d *MnemonicAddressData) Mnemonic() string {
+	return d.mnemonic
+}
+
+// MnemonicAddressGenerator derives the SLIP-10 ed25519 account at
+// MnemonicPath of each mnemonic.
+type MnemonicAddressGenerator struct {
+	*hdwallet.MnemonicGenerator
+}
+
+func NewMnemonicAddressGenerator(words int) (*MnemonicAddressGenerator, error) {
+	mg, err := hdwallet.NewMnemonicGenerator(words)
+	if err != nil {
+		return nil, err
+	}
+	return &MnemonicAddressGenerator{MnemonicGenerator: mg}, nil
+}
+
+func (g *MnemonicAddressGenerator) Generate() (string, interface{}, error) {
+	mnemonic, seed, err := g.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	privateKey, err := hdwallet.DeriveEd25519(seed, mnemonicPath)
+	if err != nil {
+		return "", nil, err
+	}
+	address := base58.Encode(privateKey.Public().(ed25519.PublicKey))
+	return address, &MnemonicAddressData{
+		address:  address,
+		mnemonic: mnemonic,
+	}, nil
+}
+
+// VanityMnemonicAddress searches mnemonics whose MnemonicPath account
+// matches config.
+func VanityMnemonicAddress(config *model.VanityConfig, words int) error {
+	log.Printf("REMINDER: Solana addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateBase58Contains(config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to --mnemonic: the phrase is printed, store it yourself")
+	}
+
+	generator, err := NewMnemonicAddressGenerator(words)
+	if err != nil {
+		return err
+	}
+	log.Printf("Searching %d-word mnemonics, account %s", words, MnemonicPath)
+
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+	searcher.LogEstimate(hdwallet.AttemptsPerSecond)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*MnemonicAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Path = MnemonicPath
+		record.Mnemonic = data.Mnemonic()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Mnemonic: %s", data.Mnemonic())
+			log.Printf("Path: %s", MnemonicPath)
+		})
+	})
+}
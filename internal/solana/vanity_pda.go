@@ -0,0 +1,204 @@
+package solana
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/mr-tron/base58"
+	"github.com/naiba/nb/model"
+)
+
+// seedSuffixAlphabet spells the search counter in ground seeds: short,
+// printable, and safe to paste into a Rust or TypeScript string literal.
+const seedSuffixAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// maxSeedSuffixLen is the length of the largest counter (MaxUint64) in
+// seedSuffixAlphabet.
+const maxSeedSuffixLen = 11
+
+// appendSeedSuffix appends counter in seedSuffixAlphabet to dst.
+func appendSeedSuffix(dst []byte, counter uint64) []byte {
+	var buf [maxSeedSuffixLen]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = seedSuffixAlphabet[counter%uint64(len(seedSuffixAlphabet))]
+		counter /= uint64(len(seedSuffixAlphabet))
+		if counter == 0 {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+type PDAAddressData struct {
+	address string
+	seeds   [][]byte // fixed seeds followed by the ground seed, without the bump
+	bump    uint8
+}
+
+// Address returns the base58 PDA.
+func (d *PDAAddressData) Address() string {
+	return d.address
+}
+
+// Seeds returns the seeds (without the bump) as strings.
+func (d *PDAAddressData) Seeds() []string {
+	seeds := make([]string, len(d.seeds))
+	for i, seed := range d.seeds {
+		seeds[i] = string(seed)
+	}
+	return seeds
+}
+
+// Bump returns the canonical bump seed.
+func (d *PDAAddressData) Bump() uint8 {
+	return d.bump
+}
+
+// PDAAddressGenerator grinds the last seed of a program-derived address:
+// seeds = fixed seeds..., seedPrefix + suffix(counter). Each candidate is the
+// address FindProgramAddress returns for those seeds, i.e. the one at the
+// canonical bump, which is what Anchor's `bump` constraint expects.
+type PDAAddressGenerator struct {
+	counter    atomic.Uint64
+	programID  solana.PublicKey
+	fixedSeeds [][]byte
+	seedPrefix string
+}
+
+// NewPDAAddressGenerator checks the seeds against the runtime limits
+// (MaxSeeds seeds of at most MaxSeedLength bytes, counting the bump).
+func NewPDAAddressGenerator(programID solana.PublicKey, fixedSeeds []string, seedPrefix string) (*PDAAddressGenerator, error) {
+	if len(fixedSeeds)+2 > solana.MaxSeeds {
+		return nil, fmt.Errorf("at most %d fixed seeds fit next to the ground seed and bump", solana.MaxSeeds-2)
+	}
+	g := &PDAAddressGenerator{programID: programID, seedPrefix: seedPrefix}
+	for _, seed := range fixedSeeds {
+		if len(seed) > solana.MaxSeedLength {
+			return nil, fmt.Errorf("seed %q is longer than %d bytes", seed, solana.MaxSeedLength)
+		}
+		g.fixedSeeds = append(g.fixedSeeds, []byte(seed))
+	}
+	if len(seedPrefix)+maxSeedSuffixLen > solana.MaxSeedLength {
+		return nil, fmt.Errorf("seed prefix must be at most %d bytes to leave room for the suffix", solana.MaxSeedLength-maxSeedSuffixLen)
+	}
+	return g, nil
+}
+
+func (g *PDAAddressGenerator) Generate() (string, interface{}, error) {
+	counter := g.counter.Add(1) - 1
+
+	seed := appendSeedSuffix([]byte(g.seedPrefix), counter)
+	address, bump, ok := g.findProgramAddress(seed)
+	if !ok {
+		// Every bump landing on the curve has odds of about 2^-256.
+		return "", nil, fmt.Errorf("no off-curve bump for seed %q", seed)
+	}
+
+	encoded := base58.Encode(address[:])
+	return encoded, &PDAAddressData{
+		address: encoded,
+		seeds:   append(g.fixedSeeds[:len(g.fixedSeeds):len(g.fixedSeeds)], seed),
+		bump:    bump,
+	}, nil
+}
+
+// findProgramAddress mirrors solana.FindProgramAddress for the fixed seeds
+// plus seed, hashing into a stack buffer instead of allocating per bump:
+// sha256(seeds... || bump || program id || "ProgramDerivedAddress"), walking
+// the bump down from 255 until the hash is off the ed25519 curve.
+func (g *PDAAddressGenerator) findProgramAddress(seed []byte) (address [32]byte, bump uint8, ok bool) {
+	var buf [solana.MaxSeeds*solana.MaxSeedLength + 1 + 32 + len(solana.PDA_MARKER)]byte
+	b := buf[:0]
+	for _, s := range g.fixedSeeds {
+		b = append(b, s...)
+	}
+	b = append(b, seed...)
+	bumpAt := len(b)
+	b = append(b, 0)
+	b = append(b, g.programID[:]...)
+	b = append(b, solana.PDA_MARKER...)
+
+	for bump := 255; bump >= 0; bump-- {
+		b[bumpAt] = byte(bump)
+		address = sha256.Sum256(b)
+		if !solana.IsOnCurve(address[:]) {
+			return address, uint8(bump), true
+		}
+	}
+	return address, 0, false
+}
+
+// Checkpoint implements model.ResumableGenerator. Seed grinding is a pure
+// counter walk; Params pins the program and seeds.
+func (g *PDAAddressGenerator) Checkpoint() model.GeneratorCheckpoint {
+	return model.GeneratorCheckpoint{
+		Counter: g.counter.Load(),
+		Params:  g.checkpointParams(),
+	}
+}
+
+// Restore implements model.ResumableGenerator.
+func (g *PDAAddressGenerator) Restore(cp model.GeneratorCheckpoint) error {
+	if cp.Params != g.checkpointParams() {
+		return fmt.Errorf("checkpoint was taken with a different program/seeds (%s)", cp.Params)
+	}
+	g.counter.Store(cp.Counter)
+	return nil
+}
+
+func (g *PDAAddressGenerator) checkpointParams() string {
+	return fmt.Sprintf("program=%s seeds=%q seed-prefix=%q", g.programID, g.fixedSeeds, g.seedPrefix)
+}
+
+// VanityPDA grinds PDA seeds for programID until the address matches config.
+func VanityPDA(config *model.VanityConfig, programID string, fixedSeeds []string, seedPrefix string) error {
+	log.Printf("REMINDER: Solana addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateBase58Contains(config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to PDAs: there is no private key")
+	}
+	program, err := solana.PublicKeyFromBase58(programID)
+	if err != nil {
+		return fmt.Errorf("invalid program id: %w", err)
+	}
+
+	generator, err := NewPDAAddressGenerator(program, fixedSeeds, seedPrefix)
+	if err != nil {
+		return err
+	}
+	log.Printf("Searching PDA of %s with seeds [%s] containing: %s", program, strings.Join(append(quoteSeeds(fixedSeeds), fmt.Sprintf("%q+suffix", seedPrefix)), ", "), config.Contains)
+
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*PDAAddressData)
+		bump := data.Bump()
+
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Seeds = data.Seeds()
+		record.Bump = &bump
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("PDA Address: %s", data.Address())
+			log.Printf("Seeds: [%s]", strings.Join(quoteSeeds(data.Seeds()), ", "))
+			log.Printf("Bump: %d", bump)
+		})
+	})
+}
+
+func quoteSeeds(seeds []string) []string {
+	quoted := make([]string, len(seeds))
+	for i, seed := range seeds {
+		quoted[i] = fmt.Sprintf("%q", seed)
+	}
+	return quoted
+}
@@ -0,0 +1,97 @@
+package solana
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+var testProgramID = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+func TestAppendSeedSuffix(t *testing.T) {
+	cases := map[uint64]string{
+		0:              "0",
+		9:              "9",
+		10:             "a",
+		61:             "Z",
+		62:             "10",
+		math.MaxUint64: "lYGhA16ahyf",
+	}
+	for counter, want := range cases {
+		if got := string(appendSeedSuffix([]byte("x-"), counter)); got != "x-"+want {
+			t.Fatalf("appendSeedSuffix(%d) = %q, want %q", counter, got, "x-"+want)
+		}
+	}
+	if got := len(appendSeedSuffix(nil, math.MaxUint64)); got != maxSeedSuffixLen {
+		t.Fatalf("MaxUint64 suffix is %d bytes, want maxSeedSuffixLen = %d", got, maxSeedSuffixLen)
+	}
+}
+
+func TestPDAAddressGenerator_MatchesFindProgramAddress(t *testing.T) {
+	gen, err := NewPDAAddressGenerator(testProgramID, []string{"config", "v2"}, "cfg-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := data.(*PDAAddressData)
+
+		var seeds [][]byte
+		for _, s := range d.Seeds() {
+			seeds = append(seeds, []byte(s))
+		}
+		want, bump, err := solana.FindProgramAddress(seeds, testProgramID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr != want.String() || d.Bump() != bump {
+			t.Fatalf("seeds %q: got %s bump %d, want %s bump %d", d.Seeds(), addr, d.Bump(), want, bump)
+		}
+		if !strings.HasPrefix(d.Seeds()[2], "cfg-") {
+			t.Fatalf("ground seed %q lost its prefix", d.Seeds()[2])
+		}
+	}
+}
+
+func TestPDAAddressGenerator_SeedLimits(t *testing.T) {
+	if _, err := NewPDAAddressGenerator(testProgramID, nil, strings.Repeat("a", solana.MaxSeedLength-maxSeedSuffixLen)); err != nil {
+		t.Fatalf("longest allowed prefix rejected: %v", err)
+	}
+	if _, err := NewPDAAddressGenerator(testProgramID, nil, strings.Repeat("a", solana.MaxSeedLength-maxSeedSuffixLen+1)); err == nil {
+		t.Fatal("prefix without room for the suffix accepted")
+	}
+	if _, err := NewPDAAddressGenerator(testProgramID, []string{strings.Repeat("a", solana.MaxSeedLength+1)}, ""); err == nil {
+		t.Fatal("oversized fixed seed accepted")
+	}
+	if _, err := NewPDAAddressGenerator(testProgramID, make([]string, solana.MaxSeeds-1), ""); err == nil {
+		t.Fatal("too many seeds accepted")
+	}
+}
+
+func TestPDAAddressGenerator_CheckpointRestore(t *testing.T) {
+	gen, _ := NewPDAAddressGenerator(testProgramID, nil, "p")
+	for i := 0; i < 20; i++ {
+		gen.Generate()
+	}
+	cp := gen.Checkpoint()
+
+	resumed, _ := NewPDAAddressGenerator(testProgramID, nil, "p")
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	want, _, _ := gen.Generate()
+	got, _, _ := resumed.Generate()
+	if got != want {
+		t.Fatalf("after restore: got %s, want %s", got, want)
+	}
+
+	other, _ := NewPDAAddressGenerator(testProgramID, nil, "q")
+	if err := other.Restore(cp); err == nil {
+		t.Fatal("restore with a different seed prefix should fail")
+	}
+}
@@ -0,0 +1,134 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/mr-tron/base58"
+	"github.com/naiba/nb/model"
+)
+
+type WithSeedAddressData struct {
+	address string
+	seed    string
+}
+
+// Address returns the base58 account address.
+func (d *WithSeedAddressData) Address() string {
+	return d.address
+}
+
+// Seed returns the seed string to pass to CreateAccountWithSeed.
+func (d *WithSeedAddressData) Seed() string {
+	return d.seed
+}
+
+// WithSeedAddressGenerator grinds the seed of a system program
+// CreateAccountWithSeed address, sha256(base || seed || owner), with the
+// seed being seedPrefix + suffix(counter). One hash per candidate and no key
+// to derive or keep secret: the base key signs the creation.
+type WithSeedAddressGenerator struct {
+	counter    atomic.Uint64
+	base       solana.PublicKey
+	owner      solana.PublicKey
+	seedPrefix string
+}
+
+// NewWithSeedAddressGenerator rejects owners the runtime refuses (ending in
+// the PDA marker) and prefixes that leave no room for the suffix.
+func NewWithSeedAddressGenerator(base, owner solana.PublicKey, seedPrefix string) (*WithSeedAddressGenerator, error) {
+	if bytes.HasSuffix(owner[:], []byte(solana.PDA_MARKER)) {
+		return nil, fmt.Errorf("illegal owner %s", owner)
+	}
+	if len(seedPrefix)+maxSeedSuffixLen > solana.MaxSeedLength {
+		return nil, fmt.Errorf("seed prefix must be at most %d bytes to leave room for the suffix", solana.MaxSeedLength-maxSeedSuffixLen)
+	}
+	return &WithSeedAddressGenerator{base: base, owner: owner, seedPrefix: seedPrefix}, nil
+}
+
+func (g *WithSeedAddressGenerator) Generate() (string, interface{}, error) {
+	counter := g.counter.Add(1) - 1
+
+	var buf [32 + solana.MaxSeedLength + 32]byte
+	b := append(buf[:0], g.base[:]...)
+	b = append(b, g.seedPrefix...)
+	b = appendSeedSuffix(b, counter)
+	seed := string(b[32:])
+	b = append(b, g.owner[:]...)
+	hash := sha256.Sum256(b)
+
+	address := base58.Encode(hash[:])
+	return address, &WithSeedAddressData{
+		address: address,
+		seed:    seed,
+	}, nil
+}
+
+// Checkpoint implements model.ResumableGenerator. Seed grinding is a pure
+// counter walk; Params pins the base, owner and seed prefix.
+func (g *WithSeedAddressGenerator) Checkpoint() model.GeneratorCheckpoint {
+	return model.GeneratorCheckpoint{
+		Counter: g.counter.Load(),
+		Params:  g.checkpointParams(),
+	}
+}
+
+// Restore implements model.ResumableGenerator.
+func (g *WithSeedAddressGenerator) Restore(cp model.GeneratorCheckpoint) error {
+	if cp.Params != g.checkpointParams() {
+		return fmt.Errorf("checkpoint was taken with a different base/owner/seed-prefix (%s)", cp.Params)
+	}
+	g.counter.Store(cp.Counter)
+	return nil
+}
+
+func (g *WithSeedAddressGenerator) checkpointParams() string {
+	return fmt.Sprintf("base=%s owner=%s seed-prefix=%q", g.base, g.owner, g.seedPrefix)
+}
+
+// VanityWithSeedAddress grinds CreateAccountWithSeed seeds for base and owner
+// until the address matches config.
+func VanityWithSeedAddress(config *model.VanityConfig, base, owner, seedPrefix string) error {
+	log.Printf("REMINDER: Solana addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateBase58Contains(config.ContainsList()...); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to --base: the account has no private key of its own")
+	}
+	basePub, err := solana.PublicKeyFromBase58(base)
+	if err != nil {
+		return fmt.Errorf("invalid base pubkey: %w", err)
+	}
+	ownerPub, err := solana.PublicKeyFromBase58(owner)
+	if err != nil {
+		return fmt.Errorf("invalid owner program id: %w", err)
+	}
+
+	generator, err := NewWithSeedAddressGenerator(basePub, ownerPub, seedPrefix)
+	if err != nil {
+		return err
+	}
+	log.Printf("Searching CreateAccountWithSeed address of base %s, owner %s, seed %q+suffix containing: %s", basePub, ownerPub, seedPrefix, config.Contains)
+
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*WithSeedAddressData)
+
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Base = basePub.String()
+		record.Owner = ownerPub.String()
+		record.Seed = data.Seed()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Seed: %q (CreateAccountWithSeed with base %s, owner %s)", data.Seed(), basePub, ownerPub)
+		})
+	})
+}
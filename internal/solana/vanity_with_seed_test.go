@@ -0,0 +1,44 @@
+package solana
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestWithSeedAddressGenerator_MatchesCreateWithSeed(t *testing.T) {
+	base := solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+	gen, err := NewWithSeedAddressGenerator(base, testProgramID, "vault-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := data.(*WithSeedAddressData)
+		if !strings.HasPrefix(d.Seed(), "vault-") {
+			t.Fatalf("seed %q lost its prefix", d.Seed())
+		}
+		want, err := solana.CreateWithSeed(base, d.Seed(), testProgramID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr != want.String() || d.Address() != addr {
+			t.Fatalf("seed %q: got %s, want %s", d.Seed(), addr, want)
+		}
+	}
+}
+
+func TestWithSeedAddressGenerator_Validation(t *testing.T) {
+	var pdaOwner solana.PublicKey
+	copy(pdaOwner[32-len(solana.PDA_MARKER):], solana.PDA_MARKER)
+	if _, err := NewWithSeedAddressGenerator(testProgramID, pdaOwner, ""); err == nil {
+		t.Fatal("owner ending in the PDA marker accepted")
+	}
+	if _, err := NewWithSeedAddressGenerator(testProgramID, solana.SystemProgramID, strings.Repeat("a", solana.MaxSeedLength-maxSeedSuffixLen+1)); err == nil {
+		t.Fatal("prefix without room for the suffix accepted")
+	}
+}
@@ -0,0 +1,123 @@
+package sui
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/naiba/nb/internal/bech32"
+	"github.com/naiba/nb/internal/ed25519keys"
+	"github.com/naiba/nb/model"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ed25519Flag is Sui's signature scheme flag for ed25519, prepended to the
+// public key for the address and to the private key for export.
+const ed25519Flag = 0x00
+
+// privateKeyHRP is the bech32 prefix of Sui's private key format (SIP-15),
+// what `sui keytool import` takes.
+const privateKeyHRP = "suiprivkey"
+
+var privateKeyEncoder = bech32.NewEncoder(privateKeyHRP)
+
+type SuiAddressData struct {
+	address    string // 64 lowercase hex characters, no 0x
+	privateKey ed25519.PrivateKey
+}
+
+// Address returns the 0x-prefixed address.
+func (d *SuiAddressData) Address() string {
+	return "0x" + d.address
+}
+
+// PrivateKeyBytes returns the 32-byte ed25519 seed.
+func (d *SuiAddressData) PrivateKeyBytes() []byte {
+	return d.privateKey.Seed()
+}
+
+// ExportedPrivateKey returns the key as suiprivkey1..., bech32 of the scheme
+// flag followed by the seed.
+func (d *SuiAddressData) ExportedPrivateKey() string {
+	return privateKeyEncoder.Encode(nil, append([]byte{ed25519Flag}, d.PrivateKeyBytes()...), false)
+}
+
+// SuiAddressGenerator derives blake2b-256(flag || pubkey) addresses from the
+// shared ed25519 key sequence.
+type SuiAddressGenerator struct {
+	*ed25519keys.KeyGenerator
+}
+
+func NewSuiAddressGenerator() (*SuiAddressGenerator, error) {
+	kg, err := ed25519keys.NewKeyGenerator()
+	if err != nil {
+		return nil, err
+	}
+	return &SuiAddressGenerator{KeyGenerator: kg}, nil
+}
+
+func (g *SuiAddressGenerator) Generate() (string, interface{}, error) {
+	privateKey := g.Next()
+
+	var input [1 + ed25519.PublicKeySize]byte
+	input[0] = ed25519Flag
+	copy(input[1:], privateKey[32:])
+	hash := blake2b.Sum256(input[:])
+
+	address := hex.EncodeToString(hash[:])
+	return address, &SuiAddressData{
+		address:    address,
+		privateKey: privateKey,
+	}, nil
+}
+
+func VanityAddress(config *model.VanityConfig) error {
+	log.Printf("REMINDER: Sui addresses are 64 lowercase hexadecimal characters (0-9, a-f) after 0x")
+
+	const validHexChars = "0123456789abcdefABCDEF"
+	for _, pattern := range config.ContainsList() {
+		for _, char := range pattern {
+			if !strings.ContainsRune(validHexChars, char) {
+				return fmt.Errorf("contains illegal character: %c (Sui addresses only contain 0-9, a-f)", char)
+			}
+		}
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask only applies to 20-byte addresses")
+	}
+	if err := config.PlaintextKeystore("Sui private key"); err != nil {
+		return err
+	}
+
+	generator, err := NewSuiAddressGenerator()
+	if err != nil {
+		return err
+	}
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.HexAlphabet).WithLowercase()
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*SuiAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		if config.KeystoreDir != "" {
+			path, err := model.WriteSecretFile(config.KeystoreDir, data.Address()+".key", []byte(data.ExportedPrivateKey()+"\n"))
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.Address())
+				log.Printf("Key File: %s", path)
+			})
+		}
+
+		record.PrivateKey = data.ExportedPrivateKey()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Private Key: %s", record.PrivateKey)
+			log.Printf("Import: sui keytool import %s ed25519", record.PrivateKey)
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package sui
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/naiba/nb/internal/ed25519keys"
+	"github.com/naiba/nb/model"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestSuiAddressGenerator_AddressDerivation(t *testing.T) {
+	gen := &SuiAddressGenerator{KeyGenerator: ed25519keys.NewKeyGeneratorFromSeed([32]byte{0x42})}
+	for i := 0; i < 100; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := data.(*SuiAddressData)
+
+		pub := ed25519.NewKeyFromSeed(d.PrivateKeyBytes()).Public().(ed25519.PublicKey)
+		h, _ := blake2b.New256(nil)
+		h.Write([]byte{0x00})
+		h.Write(pub)
+		if want := hex.EncodeToString(h.Sum(nil)); addr != want || d.Address() != "0x"+want {
+			t.Fatalf("address = %s, want %s", addr, want)
+		}
+
+		// bech32 of 33 bytes: "suiprivkey1", 53 data characters starting with
+		// the zero ed25519 flag, and a 6-character checksum.
+		exported := d.ExportedPrivateKey()
+		if !strings.HasPrefix(exported, "suiprivkey1q") || len(exported) != 70 {
+			t.Fatalf("exported key %s is not a suiprivkey ed25519 key", exported)
+		}
+	}
+}
+
+func TestVanityAddress_RejectsPassphraseFile(t *testing.T) {
+	config := &model.VanityConfig{Contains: "abc", KeystoreDir: t.TempDir(), PassphraseFile: "pass.txt"}
+	if err := VanityAddress(config); err == nil || !strings.Contains(err.Error(), "plaintext") {
+		t.Errorf("err = %v, want --passphrase-file rejected", err)
+	}
+}
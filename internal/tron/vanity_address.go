@@ -49,7 +49,13 @@ func (g *TronAddressGenerator) Generate() (string, interface{}, error) {
 	if err != nil {
 		return "", nil, err
 	}
+	address := EncodeAddress(ethAddr)
+	return address, &TronAddressData{address: address, seed: seed}, nil
+}
 
+// EncodeAddress turns the 20-byte keccak address shared with Ethereum into a
+// base58 Tron mainnet address.
+func EncodeAddress(ethAddr [20]byte) string {
 	// 25-byte payload: 0x41 || eth-addr (20) || checksum (4)
 	var payload [25]byte
 	payload[0] = 0x41
@@ -60,26 +66,74 @@ func (g *TronAddressGenerator) Generate() (string, interface{}, error) {
 	h2 := sha256.Sum256(h1[:])
 	copy(payload[21:25], h2[:4])
 
-	address := base58.Encode(payload[:])
-	return address, &TronAddressData{address: address, seed: seed}, nil
+	return base58.Encode(payload[:])
 }
 
-func VanityAddress(config *model.VanityConfig) error {
-	log.Printf("REMINDER: Tron addresses use Base58 encoding (excludes 0, O, I, l)")
+// DecodeAddress parses a Tron mainnet address given as base58 (T...),
+// 41-prefixed hex, or 0x-prefixed 20-byte hex.
+func DecodeAddress(s string) (addr [20]byte, err error) {
+	if strings.HasPrefix(s, "T") {
+		payload, err := base58.Decode(s)
+		if err != nil || len(payload) != 25 || payload[0] != 0x41 {
+			return addr, fmt.Errorf("invalid Tron address: %s", s)
+		}
+		h1 := sha256.Sum256(payload[:21])
+		h2 := sha256.Sum256(h1[:])
+		if string(h2[:4]) != string(payload[21:]) {
+			return addr, fmt.Errorf("invalid Tron address checksum: %s", s)
+		}
+		copy(addr[:], payload[1:21])
+		return addr, nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	switch {
+	case err == nil && len(b) == 21 && b[0] == 0x41 && !strings.HasPrefix(s, "0x"):
+		copy(addr[:], b[1:])
+	case err == nil && len(b) == 20 && strings.HasPrefix(s, "0x"):
+		copy(addr[:], b)
+	default:
+		return addr, fmt.Errorf("invalid Tron address: %s", s)
+	}
+	return addr, nil
+}
 
+// validateContains rejects characters outside base58 and warns about prefix
+// patterns that skip the leading 'T'.
+func validateContains(config *model.VanityConfig) error {
 	// Base58 alphabet: 123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz
 	// Excluded: 0 (zero), O (capital o), I (capital i), l (lowercase L)
 	validBase58Chars := "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-	for _, char := range config.Contains {
-		if !strings.ContainsRune(validBase58Chars, char) {
-			return fmt.Errorf("contains illegal character: %c (Tron addresses use Base58: excludes 0, O, I, l)", char)
+	for _, pattern := range config.ContainsList() {
+		for _, char := range pattern {
+			if !strings.ContainsRune(validBase58Chars, char) {
+				return fmt.Errorf("contains illegal character: %c (Tron addresses use Base58: excludes 0, O, I, l)", char)
+			}
 		}
 	}
 
 	// Tron addresses always start with 'T' for mainnet
 	if config.Mode == model.VanityModePrefix {
-		if !strings.HasPrefix(config.Contains, "T") {
-			log.Printf("WARNING: Tron mainnet addresses always start with 'T'. Your search pattern '%s' will need to match after the 'T'", config.Contains)
+		for _, pattern := range config.ContainsList() {
+			if !strings.HasPrefix(pattern, "T") {
+				log.Printf("WARNING: Tron mainnet addresses always start with 'T'. Your search pattern '%s' will need to match after the 'T'", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func VanityAddress(config *model.VanityConfig) error {
+	log.Printf("REMINDER: Tron addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateContains(config); err != nil {
+		return err
+	}
+
+	var passphrase string
+	if config.KeystoreDir != "" {
+		var err error
+		if passphrase, err = config.KeystorePassphrase(); err != nil {
+			return err
 		}
 	}
 
@@ -87,20 +141,40 @@ func VanityAddress(config *model.VanityConfig) error {
 	if err != nil {
 		return err
 	}
-	searcher := model.NewVanitySearcher(config, generator)
-
-	result, err := searcher.Search(context.Background())
+	split, err := ethereum.ApplySplitKey(config, generator.SecpKeyGenerator)
 	if err != nil {
 		return err
 	}
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*TronAddressData)
+		record := model.NewVanityRecord(data.address, result)
+		if split {
+			record.PartialKey = hex.EncodeToString(data.PrivateKeyBytes())
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.address)
+				log.Printf("Partial Private Key (hex): %s", record.PartialKey)
+			})
+		}
+		if config.KeystoreDir != "" {
+			path, err := ethereum.WriteKeystore(config.KeystoreDir, data.address+".json", data.PrivateKeyBytes(), passphrase)
+			if err != nil {
+				return err
+			}
+			record.KeyFile = path
+			return model.ReportVanityResult(config, record, func() {
+				log.Printf("Address: %s", data.address)
+				log.Printf("Keystore: %s", path)
+			})
+		}
 
-	data := result.Data.(*TronAddressData)
-
-	privateKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
-
-	log.Printf("Address: %s", data.address)
-	log.Printf("Private Key (hex): %s", privateKeyHex)
-	log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
-
-	return nil
+		privateKeyHex := hex.EncodeToString(data.PrivateKeyBytes())
+		record.PrivateKey = privateKeyHex
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.address)
+			log.Printf("Private Key (hex): %s", privateKeyHex)
+			log.Printf("Private Key (with 0x prefix): 0x%s", privateKeyHex)
+		})
+	})
 }
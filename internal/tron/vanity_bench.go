@@ -0,0 +1,12 @@
+package tron
+
+import "github.com/naiba/nb/model"
+
+func init() {
+	model.RegisterVanityBenchTarget(model.VanityBenchTarget{
+		Name:      "tron",
+		New:       func() (model.AddressGenerator, error) { return NewTronAddressGenerator() },
+		Configure: func(s *model.VanitySearcher) { s.WithAlphabet(model.Base58Alphabet) },
+		Validate:  validateContains,
+	})
+}
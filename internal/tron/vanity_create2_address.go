@@ -0,0 +1,111 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/model"
+)
+
+// create2HashPrefix replaces EIP-1014's 0xff in TVM's CREATE2:
+//
+//	addr = keccak256(0x41 || deployer(20) || salt(32) || keccak256(initCode))[12:]
+const create2HashPrefix = 0x41
+
+type Create2AddressData struct {
+	*ethereum.Create2AddressData
+	address string
+}
+
+// Address returns the base58 Tron address.
+func (d *Create2AddressData) Address() string {
+	return d.address
+}
+
+// Create2AddressGenerator reuses the Ethereum CREATE2 template with Tron's
+// hash prefix and base58check-encodes each candidate.
+//
+// There is no CREATE1 counterpart: a contract deployed by a CreateSmartContract
+// transaction gets sha3omit12(txID || owner), and TVM's CREATE hashes the
+// root transaction id with an internal nonce. Both depend on the transaction
+// itself (reference block, timestamp), so no address can be precomputed from
+// the deployer alone.
+type Create2AddressGenerator struct {
+	*ethereum.Create2AddressGenerator
+}
+
+func NewCreate2AddressGenerator(deployer [20]byte, saltPrefix string, scheme ethereum.Create2SaltScheme, contractBin string, constructorArgs []string) (*Create2AddressGenerator, error) {
+	if scheme.Name == ethereum.SaltSchemeCreateX {
+		return nil, fmt.Errorf("salt scheme %s is not available on Tron: CreateX is not deployed there", ethereum.SaltSchemeCreateX)
+	}
+	inner, err := ethereum.NewCreate2AddressGenerator(common.Address(deployer).Hex(), saltPrefix, contractBin, constructorArgs)
+	if err != nil {
+		return nil, err
+	}
+	inner.SetHashPrefix(create2HashPrefix)
+	if err := inner.SetSaltScheme(scheme); err != nil {
+		return nil, err
+	}
+	return &Create2AddressGenerator{Create2AddressGenerator: inner}, nil
+}
+
+func (g *Create2AddressGenerator) Generate() (string, interface{}, error) {
+	_, data, err := g.Create2AddressGenerator.Generate()
+	if err != nil {
+		return "", nil, err
+	}
+	inner := data.(*ethereum.Create2AddressData)
+	address := EncodeAddress(inner.AddressBytes())
+	return address, &Create2AddressData{Create2AddressData: inner, address: address}, nil
+}
+
+func VanityCreate2Address(config *model.VanityConfig, deployer, saltPrefix string, scheme ethereum.Create2SaltScheme, contractBin string, constructorArgs []string) error {
+	log.Printf("REMINDER: Tron addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateContains(config); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to CREATE2: the search yields a salt, not a private key")
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask does not apply to base58 Tron addresses")
+	}
+	deployerAddr, err := DecodeAddress(deployer)
+	if err != nil {
+		return err
+	}
+
+	generator, err := NewCreate2AddressGenerator(deployerAddr, saltPrefix, scheme, contractBin, constructorArgs)
+	if err != nil {
+		return err
+	}
+	deployerTron := EncodeAddress(deployerAddr)
+	log.Printf("Searching for Tron CREATE2 address with deployer: %s", deployerTron)
+	log.Printf("Salt scheme: %s", scheme.String())
+
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*Create2AddressData)
+		salt := data.Salt()
+
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Deployer = deployerTron
+		record.Salt = hexutil.Encode(salt[:])
+		record.SaltPreimage = data.SaltString()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			if data.SaltString() != "" {
+				log.Printf("Salt: %s", data.SaltString())
+				log.Printf("Salt (keccak256): 0x%x", salt)
+			} else {
+				log.Printf("Salt (bytes32): 0x%x", salt)
+			}
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package tron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/naiba/nb/internal/ethereum"
+)
+
+// testDeployer is the USDT contract on Tron mainnet, used only as a
+// well-formed 20-byte address.
+const testDeployer = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+// TestCreate2AddressGenerator_DerivationCorrect re-derives each address with
+// TVM's formula, keccak256(0x41 || deployer || salt || keccak256(initCode))[12:].
+func TestCreate2AddressGenerator_DerivationCorrect(t *testing.T) {
+	deployer, err := DecodeAddress(testDeployer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initCode := common.FromHex("0x6080604052348015")
+	gen, err := NewCreate2AddressGenerator(deployer, "prefix", ethereum.Create2SaltScheme{}, "0x6080604052348015", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 200; i++ {
+		addr, data, err := gen.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		salt := data.(*Create2AddressData).Salt()
+		hash := crypto.Keccak256([]byte{0x41}, deployer[:], salt[:], crypto.Keccak256(initCode))
+		var want [20]byte
+		copy(want[:], hash[12:])
+		if want := EncodeAddress(want); addr != want {
+			t.Fatalf("salt %x: got %s, want %s", salt, addr, want)
+		}
+		if !strings.HasPrefix(addr, "T") {
+			t.Fatalf("address %s doesn't start with T", addr)
+		}
+	}
+}
+
+func TestCreate2AddressGenerator_RejectsCreateX(t *testing.T) {
+	deployer, _ := DecodeAddress(testDeployer)
+	if _, err := NewCreate2AddressGenerator(deployer, "", ethereum.Create2SaltScheme{Name: ethereum.SaltSchemeCreateX}, "0x00", nil); err == nil {
+		t.Fatal("createx salt scheme accepted on Tron")
+	}
+}
+
+func TestCreate2AddressGenerator_CheckpointPinsHashPrefix(t *testing.T) {
+	deployer, _ := DecodeAddress(testDeployer)
+	gen, _ := NewCreate2AddressGenerator(deployer, "p", ethereum.Create2SaltScheme{}, "0x00", nil)
+	for i := 0; i < 10; i++ {
+		gen.Generate()
+	}
+	cp := gen.Checkpoint()
+
+	eth, _ := ethereum.NewCreate2AddressGenerator(common.Address(deployer).Hex(), "p", "0x00", nil)
+	if err := eth.Restore(cp); err == nil {
+		t.Fatal("Ethereum CREATE2 generator accepted a Tron checkpoint")
+	}
+}
+
+func TestDecodeAddress(t *testing.T) {
+	want, err := DecodeAddress(testDeployer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EncodeAddress(want); got != testDeployer {
+		t.Fatalf("round trip: got %s, want %s", got, testDeployer)
+	}
+	for _, s := range []string{"41" + common.Bytes2Hex(want[:]), "0x" + common.Bytes2Hex(want[:])} {
+		if got, err := DecodeAddress(s); err != nil || got != want {
+			t.Fatalf("DecodeAddress(%s) = %x, %v; want %x", s, got, err, want)
+		}
+	}
+	for _, s := range []string{
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", // checksum
+		"42" + common.Bytes2Hex(want[:]),     // wrong prefix byte
+		common.Bytes2Hex(want[:]),            // bare 20-byte hex
+		"T",
+	} {
+		if _, err := DecodeAddress(s); err == nil {
+			t.Fatalf("DecodeAddress(%s) accepted", s)
+		}
+	}
+}
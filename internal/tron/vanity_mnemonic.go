@@ -0,0 +1,88 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/naiba/nb/internal/ethereum"
+	"github.com/naiba/nb/internal/hdwallet"
+	"github.com/naiba/nb/model"
+)
+
+// MnemonicPath is TronLink's first account (SLIP-44 coin type 195).
+const MnemonicPath = "m/44'/195'/0'/0/0"
+
+type MnemonicAddressData struct {
+	*ethereum.MnemonicAddressData
+	address string
+}
+
+// Address returns the base58 Tron address.
+func (d *MnemonicAddressData) Address() string {
+	return d.address
+}
+
+// MnemonicAddressGenerator derives the Ethereum-style account at
+// MnemonicPath and base58check-encodes it.
+type MnemonicAddressGenerator struct {
+	*ethereum.MnemonicAddressGenerator
+}
+
+func NewMnemonicAddressGenerator(words int) (*MnemonicAddressGenerator, error) {
+	inner, err := ethereum.NewMnemonicAddressGenerator(words, MnemonicPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MnemonicAddressGenerator{MnemonicAddressGenerator: inner}, nil
+}
+
+func (g *MnemonicAddressGenerator) Generate() (string, interface{}, error) {
+	_, data, err := g.MnemonicAddressGenerator.Generate()
+	if err != nil {
+		return "", nil, err
+	}
+	inner := data.(*ethereum.MnemonicAddressData)
+	address := EncodeAddress(inner.AddressBytes())
+	return address, &MnemonicAddressData{MnemonicAddressData: inner, address: address}, nil
+}
+
+// VanityMnemonicAddress searches mnemonics whose MnemonicPath account
+// matches config.
+func VanityMnemonicAddress(config *model.VanityConfig, words int) error {
+	log.Printf("REMINDER: Tron addresses use Base58 encoding (excludes 0, O, I, l)")
+
+	if err := validateContains(config); err != nil {
+		return err
+	}
+	if config.KeystoreDir != "" {
+		return fmt.Errorf("--keystore does not apply to --mnemonic: the phrase is printed, store it yourself")
+	}
+	if config.SplitKey != "" {
+		return fmt.Errorf("--split-key does not apply to --mnemonic")
+	}
+	if config.Mask != nil {
+		return fmt.Errorf("--mask does not apply to base58 Tron addresses")
+	}
+
+	generator, err := NewMnemonicAddressGenerator(words)
+	if err != nil {
+		return err
+	}
+	log.Printf("Searching %d-word mnemonics, account %s", words, MnemonicPath)
+
+	searcher := model.NewVanitySearcher(config, generator).WithAlphabet(model.Base58Alphabet)
+	searcher.LogEstimate(hdwallet.AttemptsPerSecond)
+
+	return searcher.SearchEach(context.Background(), func(result *model.VanityResult) error {
+		data := result.Data.(*MnemonicAddressData)
+		record := model.NewVanityRecord(data.Address(), result)
+		record.Path = MnemonicPath
+		record.Mnemonic = data.Mnemonic()
+		return model.ReportVanityResult(config, record, func() {
+			log.Printf("Address: %s", data.Address())
+			log.Printf("Mnemonic: %s", data.Mnemonic())
+			log.Printf("Path: %s", MnemonicPath)
+		})
+	})
+}
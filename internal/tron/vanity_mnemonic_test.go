@@ -0,0 +1,35 @@
+package tron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/naiba/nb/internal/hdwallet"
+	"github.com/naiba/nb/model"
+)
+
+// TestMnemonicAddressGenerator_KnownVector checks the all-zero entropy
+// mnemonic ("abandon ... about") against the account TronLink derives.
+func TestMnemonicAddressGenerator_KnownVector(t *testing.T) {
+	gen, err := NewMnemonicAddressGenerator(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gen.MnemonicGenerator, err = hdwallet.NewMnemonicGeneratorFromEntropy(make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	}
+	addr, _, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH"; addr != want {
+		t.Fatalf("got %s, want %s", addr, want)
+	}
+}
+
+func TestVanityMnemonicAddress_RejectsKeystore(t *testing.T) {
+	config := &model.VanityConfig{Contains: "TAb", KeystoreDir: t.TempDir()}
+	if err := VanityMnemonicAddress(config, 12); err == nil || !strings.Contains(err.Error(), "--keystore") {
+		t.Errorf("err = %v, want --keystore rejected", err)
+	}
+}
@@ -18,6 +18,7 @@ func main() {
 	go func() {
 		<-signalChain
 		if killed.CompareAndSwap(false, true) {
+			internal.RunInterruptHooks()
 			internal.CleanupChildProcesses(true)
 			os.Exit(1)
 		}
@@ -29,7 +30,7 @@ func main() {
 	}()
 	err := cmd.Execute()
 	if err != nil {
-		fmt.Println("Error: ", err)
+		fmt.Fprintln(os.Stderr, "Error: ", err)
 		os.Exit(1)
 	}
 }
@@ -1,6 +1,12 @@
 package model
 
 import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -8,7 +14,72 @@ type GitAccount struct {
 	Name       string
 	Email      string
 	SSHPrikey  string
-	SSHSignKey string
+	SSHSignKey string // public key file, or the key itself, for ssh signing
+	GPGSignKey string // key ID or fingerprint for openpgp signing
+	// SignMode is ssh, openpgp or none. When empty it follows the keys:
+	// ssh with SSHSignKey, openpgp with GPGSignKey, otherwise none.
+	SignMode string
+	// SignCommits and SignTags set commit.gpgsign and tag.gpgsign; commits
+	// are signed by default whenever a sign mode is on, tags only on request.
+	SignCommits *bool
+	SignTags    *bool
+}
+
+// Sign modes of a GitAccount.
+const (
+	GitSignModeSSH     = "ssh"
+	GitSignModeOpenPGP = "openpgp"
+	GitSignModeNone    = "none"
+)
+
+// GetSignMode returns the account's effective sign mode.
+func (ga GitAccount) GetSignMode() (string, error) {
+	switch mode := strings.ToLower(ga.SignMode); mode {
+	case "":
+		if ga.SSHSignKey != "" {
+			return GitSignModeSSH, nil
+		}
+		if ga.GPGSignKey != "" {
+			return GitSignModeOpenPGP, nil
+		}
+		return GitSignModeNone, nil
+	case GitSignModeSSH:
+		if ga.SSHSignKey == "" {
+			return "", fmt.Errorf("sign mode ssh needs sshsignkey")
+		}
+		return mode, nil
+	case GitSignModeOpenPGP, GitSignModeNone:
+		// openpgp without a key lets gpg pick one by the committer email.
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown sign mode %q, want %s, %s or %s", ga.SignMode, GitSignModeSSH, GitSignModeOpenPGP, GitSignModeNone)
+	}
+}
+
+// AutoSign reports whether commits and tags get signed without -S/-s.
+func (ga GitAccount) AutoSign() (commits, tags bool) {
+	if mode, err := ga.GetSignMode(); err != nil || mode == GitSignModeNone {
+		return false, false
+	}
+	commits, tags = true, false
+	if ga.SignCommits != nil {
+		commits = *ga.SignCommits
+	}
+	if ga.SignTags != nil {
+		tags = *ga.SignTags
+	}
+	return commits, tags
+}
+
+// GitRule selects a git account when -gu is omitted. Remote is a glob over
+// the origin remote normalized to host:path (github.com:acme/*), Path a glob
+// over the working directory (~/work/**); "*" stays within one path segment
+// and "**" spans any number. A rule needs at least one of the two and
+// matches when any of its set patterns does.
+type GitRule struct {
+	Account string
+	Remote  string
+	Path    string
 }
 
 type SSHAccount struct {
@@ -31,11 +102,12 @@ type Proxy struct {
 }
 
 type Config struct {
-	Banner  string
-	Git     map[string]GitAccount
-	SSH     map[string]SSHAccount
-	Proxy   map[string]Proxy
-	Snippet map[string]string
+	Banner   string
+	Git      map[string]GitAccount
+	GitRules []GitRule // tried in order when -gu is omitted; the first match wins
+	SSH      map[string]SSHAccount
+	Proxy    map[string]Proxy
+	Snippet  map[string]string
 }
 
 func ReadInConfig(path string) (*Config, bool, error) {
@@ -63,3 +135,78 @@ func ReadInConfig(path string) (*Config, bool, error) {
 	}
 	return &config, true, nil
 }
+
+// MatchGitRule returns the first rule matching remoteURL (may be empty) or
+// dir, or nil. home expands a leading ~ in path patterns.
+func (c *Config) MatchGitRule(remoteURL, dir, home string) *GitRule {
+	remote := NormalizeGitRemote(remoteURL)
+	dir = filepath.ToSlash(filepath.Clean(dir))
+	for i := range c.GitRules {
+		rule := &c.GitRules[i]
+		if rule.Remote != "" && remote != "" && matchGlob(strings.ToLower(rule.Remote), remote) {
+			return rule
+		}
+		if rule.Path != "" && dir != "" && matchGlob(expandHome(rule.Path, home), dir) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// scpLikeRemote matches git's scp-like syntax, [user@]host:path.
+var scpLikeRemote = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// NormalizeGitRemote turns a remote URL (ssh://, https://, git@host:path)
+// into lowercase host:path without user, port or a trailing .git, e.g.
+// github.com:acme/repo. Returns "" for local paths and unparsable URLs.
+func NormalizeGitRemote(remoteURL string) string {
+	remoteURL = strings.TrimSpace(remoteURL)
+	var host, path string
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Hostname() == "" {
+			return ""
+		}
+		host, path = u.Hostname(), u.Path
+	} else if m := scpLikeRemote.FindStringSubmatch(remoteURL); m != nil {
+		host, path = m[1], m[2]
+	} else {
+		return ""
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return strings.ToLower(host + ":" + path)
+}
+
+func expandHome(pattern, home string) string {
+	if home != "" && (pattern == "~" || strings.HasPrefix(pattern, "~/")) {
+		pattern = home + pattern[1:]
+	}
+	return filepath.ToSlash(pattern)
+}
+
+// matchGlob reports whether s matches pattern, where "*" and "?" stay within
+// a path segment and "**" spans segments. A trailing "/**" also matches the
+// directory itself.
+func matchGlob(pattern, s string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			re.WriteString("(?:/.*)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	matched, err := regexp.MatchString(re.String(), s)
+	return err == nil && matched
+}
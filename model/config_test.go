@@ -0,0 +1,111 @@
+package model
+
+import "testing"
+
+func TestNormalizeGitRemote(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:acme/repo.git":          "github.com:acme/repo",
+		"git@GitHub.com:Acme/Repo":              "github.com:acme/repo",
+		"ssh://git@github.com:22/acme/repo.git": "github.com:acme/repo",
+		"https://github.com/acme/repo.git":      "github.com:acme/repo",
+		"https://user@gitlab.com/group/sub/r/":  "gitlab.com:group/sub/r",
+		"/srv/git/repo.git":                     "",
+		"../repo":                               "",
+		"":                                      "",
+	}
+	for in, want := range tests {
+		if got := NormalizeGitRemote(in); got != want {
+			t.Errorf("NormalizeGitRemote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestConfig_MatchGitRule(t *testing.T) {
+	config := &Config{GitRules: []GitRule{
+		{Account: "acme", Remote: "github.com:acme/*"},
+		{Account: "gitlab", Remote: "gitlab.com:corp/**"},
+		{Account: "work", Path: "~/work/**"},
+		{Account: "scratch", Path: "/tmp/*/scratch"},
+	}}
+	const home = "/home/me"
+	tests := []struct {
+		name   string
+		remote string
+		dir    string
+		want   string
+	}{
+		{"org glob", "git@github.com:acme/api.git", "/home/me/src/api", "acme"},
+		{"org glob is case-insensitive", "https://github.com/ACME/api", "/", "acme"},
+		{"* stays in one segment", "git@github.com:acme-labs/api.git", "/", ""},
+		{"** spans nested groups", "git@gitlab.com:corp/team/svc.git", "/", "gitlab"},
+		{"path under home", "", "/home/me/work/a/b", "work"},
+		{"trailing /** matches the dir itself", "", "/home/me/work", "work"},
+		{"path prefix is not enough", "", "/home/me/workshop", ""},
+		{"remote wins by order", "git@github.com:acme/x", "/home/me/work/x", "acme"},
+		{"falls through to path", "git@github.com:other/x", "/home/me/work/x", "work"},
+		{"single-segment *", "", "/tmp/a/scratch", "scratch"},
+		{"nothing matches", "git@github.com:other/x", "/srv", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if rule := config.MatchGitRule(tt.remote, tt.dir, home); rule != nil {
+				got = rule.Account
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitAccount_GetSignMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		account GitAccount
+		want    string
+		wantErr bool
+	}{
+		{"no keys", GitAccount{}, GitSignModeNone, false},
+		{"ssh key", GitAccount{SSHSignKey: "~/.ssh/id.pub"}, GitSignModeSSH, false},
+		{"gpg key", GitAccount{GPGSignKey: "ABCD"}, GitSignModeOpenPGP, false},
+		{"explicit none", GitAccount{SSHSignKey: "~/.ssh/id.pub", SignMode: "none"}, GitSignModeNone, false},
+		{"openpgp by email", GitAccount{SignMode: "OpenPGP"}, GitSignModeOpenPGP, false},
+		{"ssh without key", GitAccount{SignMode: "ssh"}, "", true},
+		{"unknown", GitAccount{SignMode: "x509"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.account.GetSignMode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitAccount_AutoSign(t *testing.T) {
+	no := false
+	yes := true
+	tests := []struct {
+		name          string
+		account       GitAccount
+		commits, tags bool
+	}{
+		{"not signing", GitAccount{SignTags: &yes}, false, false},
+		{"defaults", GitAccount{SSHSignKey: "k"}, true, false},
+		{"tags too", GitAccount{SSHSignKey: "k", SignTags: &yes}, true, true},
+		{"commits off", GitAccount{GPGSignKey: "k", SignCommits: &no}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, tags := tt.account.AutoSign()
+			if commits != tt.commits || tags != tt.tags {
+				t.Errorf("got (%v, %v), want (%v, %v)", commits, tags, tt.commits, tt.tags)
+			}
+		})
+	}
+}
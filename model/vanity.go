@@ -3,12 +3,14 @@ package model
 import (
 	"fmt"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v3"
 )
 
-// VanityMode selects how config.Contains is matched against candidate addresses.
+// VanityMode selects how each config.Contains alternative is matched against candidate addresses.
 type VanityMode int
 
 const (
@@ -19,13 +21,37 @@ const (
 )
 
 type VanityConfig struct {
-	Contains      string
+	Contains      string // one pattern, or comma-separated alternatives (any may match)
+	Regex         string // optional RE2 expression the address must also match
 	Mode          VanityMode
 	CaseSensitive bool
 	UpperOrLower  bool
 	Threads       int
 	Mask          []byte // (address & Mask) == MaskValue
 	MaskValue     []byte
+	StatePath     string        // checkpoint file; resumed from if it already exists
+	Count         int           // number of distinct matches to find before stopping
+	Progress      time.Duration // status line interval; 0 disables progress reporting
+	Output        string        // VanityOutputText or VanityOutputJSON
+
+	// Scorer switches to a best-so-far search: Contains feeds the scorer
+	// instead of filtering, and every strict improvement is reported until
+	// Budget or MaxAttempts (approximate) runs out, or the search is stopped.
+	Scorer      VanityScorer
+	Budget      time.Duration
+	MaxAttempts uint64
+	Target      int // stop once a candidate scores at least this; 0 = no target
+
+	// SplitKey is a hex secp256k1 public key. When set, secp256k1 searches
+	// look for a partial private key whose point, added to this one, gives
+	// the vanity address; the owner combines the two secrets offline.
+	SplitKey string
+
+	// KeystoreDir, when set, receives each found key as a file instead of
+	// the key being printed.
+	KeystoreDir    string
+	PassphraseFile string
+	passphrase     string // resolved by KeystorePassphrase
 }
 
 func VanityFlags() []cli.Flag {
@@ -33,7 +59,11 @@ func VanityFlags() []cli.Flag {
 		&cli.StringFlag{
 			Name:    "contains",
 			Aliases: []string{"c"},
-			Usage:   "The address must contain this string.",
+			Usage:   "The address must contain this string. Separate alternatives with commas, e.g. cafe,beef,f00d.",
+		},
+		&cli.StringFlag{
+			Name:  "regex",
+			Usage: "The address must match this regular expression (Ethereum: without 0x), e.g. ^0{6} or (.)\\1{5}$.",
 		},
 		&cli.StringFlag{
 			Name:    "mode",
@@ -60,26 +90,94 @@ func VanityFlags() []cli.Flag {
 			Name:  "mask-value",
 			Usage: "Target value for bitmask match in hex (e.g., 0xB0B0000000000000000000000000000000002280).",
 		},
+		&cli.IntFlag{
+			Name:    "count",
+			Aliases: []string{"n"},
+			Usage:   "Number of distinct matches to find; each one is printed as soon as it is found.",
+			Value:   1,
+		},
+		&cli.DurationFlag{
+			Name:  "progress",
+			Usage: "Print a status line (rate, attempts, elapsed, match probability) at this interval, e.g. 10s, plus a summary at the end. 0 disables.",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Result format: text, or json (one object per result on stdout; logs stay on stderr).",
+			Value:   VanityOutputText,
+		},
+		&cli.StringFlag{
+			Name:  "score",
+			Usage: "Keep the best-scoring address instead of an exact match, printing each improvement: leading-zeros, leading-zero-bytes, zero-bytes (hex), repeat (longest run of one character), pattern (characters in place from --contains).",
+		},
+		&cli.DurationFlag{
+			Name:  "budget",
+			Usage: "With --score, stop after this long, e.g. 10m.",
+		},
+		&cli.IntFlag{
+			Name:  "max-attempts",
+			Usage: "With --score, stop after roughly this many candidates.",
+		},
+		&cli.StringFlag{
+			Name:  "keystore",
+			Usage: "Write each found key into this directory instead of printing it: encrypted keystore v3 JSON for Ethereum/Tron; other chains write their wallet import format in plaintext (mode 0600), see the command's help.",
+		},
+		&cli.StringFlag{
+			Name:  "passphrase-file",
+			Usage: "File holding the encrypted keystore's passphrase (default: $NB_KEYSTORE_PASSPHRASE, then an interactive prompt).",
+		},
+		&cli.StringFlag{
+			Name:  "resume",
+			Usage: "State file to checkpoint progress into; if it exists, the search continues from where it stopped. Keep it private: it can regenerate the keys.",
+		},
+	}
+}
+
+// VanitySecpFlags returns CLI flags for secp256k1 key searches (EOA, CREATE1
+// deployer, Tron), which also support split-key mode.
+func VanitySecpFlags() []cli.Flag {
+	return append(VanityFlags(), &cli.StringFlag{
+		Name:  "split-key",
+		Usage: "Your secp256k1 public key (hex, compressed or uncompressed). The search then yields a partial private key that is useless on its own; add it to your secret locally with `nb ethereum combine`.",
+	})
+}
+
+// VanityMnemonicFlags returns the flags that switch a key search to BIP-39
+// mnemonics.
+func VanityMnemonicFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "mnemonic",
+			Usage: "Search BIP-39 mnemonics and match the chain's standard BIP-44 account. Far slower than raw keys. The phrase is printed; --keystore does not apply.",
+		},
+		&cli.IntFlag{
+			Name:  "words",
+			Usage: "Mnemonic length with --mnemonic: 12 or 24.",
+			Value: 12,
+		},
 	}
 }
 
+// VanityCreate1Flags returns the flags for CREATE1 contract address search.
+func VanityCreate1Flags() []cli.Flag {
+	return append(VanitySecpFlags(),
+		&cli.Uint64Flag{
+			Name:  "max-nonce",
+			Usage: "Accept a match at any deployer nonce up to this one; burn that many transactions before deploying.",
+		},
+		&cli.StringFlag{
+			Name:    "deployer",
+			Aliases: []string{"d"},
+			Usage:   "Scan nonces 0..max-nonce of this existing deployer instead of searching for a new key.",
+		},
+	)
+}
+
 // VanityCreate2Flags returns CLI flags for CREATE2 vanity address generation
 func VanityCreate2Flags() []cli.Flag {
-	flags := VanityFlags()
+	flags := VanityFactoryFlags()
 	// Add CREATE2 specific flags
 	create2Flags := []cli.Flag{
-		&cli.StringFlag{
-			Name:     "deployer",
-			Aliases:  []string{"d"},
-			Usage:    "The deployer address.",
-			Required: true,
-		},
-		&cli.StringFlag{
-			Name:    "salt-prefix",
-			Aliases: []string{"sp"},
-			Usage:   "The prefix of the salt. keccak256(salt-prefix + randSaltSuffix)",
-			Value:   "",
-		},
 		&cli.StringFlag{
 			Name:     "contract-bin",
 			Aliases:  []string{"cb"},
@@ -95,8 +193,58 @@ func VanityCreate2Flags() []cli.Flag {
 	return append(flags, create2Flags...)
 }
 
-func ParseVanityConfig(cmd *cli.Command) (*VanityConfig, error) {
+// VanityFactoryFlags returns CLI flags shared by the factory-based searches
+// (CREATE2, CREATE3): deployer, salt construction and zero-byte targets.
+func VanityFactoryFlags() []cli.Flag {
+	flags := VanityFlags()
+	factoryFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "deployer",
+			Aliases: []string{"d"},
+			Usage:   "The deployer (factory) address. Defaults to CreateX with --salt-scheme createx.",
+		},
+		&cli.StringFlag{
+			Name:    "salt-prefix",
+			Aliases: []string{"sp"},
+			Usage:   "The prefix of the salt. keccak256(salt-prefix + randSaltSuffix)",
+			Value:   "",
+		},
+		&cli.StringFlag{
+			Name:  "salt-scheme",
+			Usage: "How the bytes32 salt is built: keccak (keccak256 of salt-prefix + counter), counter (raw counter), address (--salt-address in the top 20 bytes), createx (CreateX caller + protection flag layout).",
+			Value: "keccak",
+		},
+		&cli.StringFlag{
+			Name:  "salt-address",
+			Usage: "Address for the top 20 bytes of the salt (address scheme), or the CreateX caller (createx scheme; omit for no sender protection).",
+		},
+		&cli.Uint64Flag{
+			Name:  "chain-id",
+			Usage: "createx scheme: enable cross-chain redeploy protection, pinning the address to this chain.",
+		},
+		&cli.IntFlag{
+			Name:  "leading-zero-bytes",
+			Usage: "Mine for this many leading zero bytes, printing each better salt on the way (shorthand for --score leading-zero-bytes).",
+		},
+		&cli.IntFlag{
+			Name:  "zero-bytes-total",
+			Usage: "Mine for this many zero bytes anywhere in the address, printing each better salt on the way (shorthand for --score zero-bytes).",
+		},
+	}
+	return append(flags, factoryFlags...)
+}
+
+// ParseVanityConfig reads the shared vanity flags. alphabet is the one the
+// command's addresses are written in (HexAlphabet, Base58Alphabet, ...); a
+// --score that means nothing in it is rejected.
+func ParseVanityConfig(cmd *cli.Command, alphabet string) (*VanityConfig, error) {
 	contains := cmd.String("contains")
+	regex := cmd.String("regex")
+	score := cmd.String("score")
+	target, err := zeroBytesTarget(cmd, &score)
+	if err != nil {
+		return nil, err
+	}
 	modeStr := cmd.String("mode")
 	caseStr := cmd.String("case")
 	threadsStr := cmd.String("threads")
@@ -105,8 +253,18 @@ func ParseVanityConfig(cmd *cli.Command) (*VanityConfig, error) {
 
 	hasMask := maskStr != "" || maskValueStr != ""
 
-	if contains == "" && !hasMask {
-		return nil, fmt.Errorf("--contains or --mask/--mask-value is required")
+	if contains == "" && regex == "" && !hasMask && score == "" {
+		return nil, fmt.Errorf("--contains, --regex, --mask/--mask-value or --score is required")
+	}
+
+	if contains != "" && strings.Trim(contains, ",") == "" {
+		return nil, fmt.Errorf("--contains has no pattern between the commas")
+	}
+
+	if regex != "" {
+		if _, _, err := compileVanityRegex(regex); err != nil {
+			return nil, err
+		}
 	}
 
 	if hasMask && (maskStr == "" || maskValueStr == "") {
@@ -127,52 +285,149 @@ func ParseVanityConfig(cmd *cli.Command) (*VanityConfig, error) {
 		}
 	}
 
-	var mode VanityMode
+	mode, err := parseVanityMode(modeStr)
+	if err != nil {
+		return nil, err
+	}
+	caseSensitive, upperOrLower, err := parseVanityCase(caseStr)
+	if err != nil {
+		return nil, err
+	}
+	if regex != "" && upperOrLower {
+		return nil, fmt.Errorf("--case either is not supported with --regex; use a character class such as [a-f] or [A-F]")
+	}
+
+	count := cmd.Int("count")
+	if count < 1 {
+		return nil, fmt.Errorf("count must be a positive integer")
+	}
+
+	budget := cmd.Duration("budget")
+	maxAttempts := cmd.Int("max-attempts")
+	if maxAttempts < 0 {
+		return nil, fmt.Errorf("max-attempts must not be negative")
+	}
+	if score == "" && (budget > 0 || maxAttempts > 0) {
+		return nil, fmt.Errorf("--budget and --max-attempts only apply to --score")
+	}
+	if score != "" && cmd.IsSet("count") {
+		return nil, fmt.Errorf("--count does not apply to --score: every improvement is reported")
+	}
+	if score != "" && cmd.String("resume") != "" {
+		return nil, fmt.Errorf("--resume is not supported with --score")
+	}
+
+	splitKey := cmd.String("split-key")
+	if splitKey != "" && cmd.String("keystore") != "" {
+		return nil, fmt.Errorf("--keystore does not apply to --split-key: the search only yields a partial key")
+	}
+
+	output := cmd.String("output")
+	if output != VanityOutputText && output != VanityOutputJSON {
+		return nil, fmt.Errorf("output must be one of: text, json")
+	}
+
+	var threads int
+	if threadsStr == "auto" {
+		threads = runtime.NumCPU()
+	} else {
+		var err error
+		if _, err = fmt.Sscanf(threadsStr, "%d", &threads); err != nil || threads < 1 {
+			return nil, fmt.Errorf("threads must be a positive integer or 'auto'")
+		}
+	}
+
+	config := &VanityConfig{
+		Contains:       contains,
+		Regex:          regex,
+		Mode:           mode,
+		CaseSensitive:  caseSensitive,
+		UpperOrLower:   upperOrLower,
+		Threads:        threads,
+		Mask:           mask,
+		MaskValue:      maskValue,
+		StatePath:      cmd.String("resume"),
+		Count:          count,
+		Progress:       cmd.Duration("progress"),
+		Output:         output,
+		KeystoreDir:    cmd.String("keystore"),
+		PassphraseFile: cmd.String("passphrase-file"),
+		SplitKey:       splitKey,
+		Budget:         budget,
+		MaxAttempts:    uint64(maxAttempts),
+		Target:         target,
+	}
+	if score != "" {
+		if err := checkScorerAlphabet(score, alphabet); err != nil {
+			return nil, err
+		}
+		if config.Scorer, err = NewVanityScorer(score, config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+func parseVanityMode(modeStr string) (VanityMode, error) {
 	switch modeStr {
 	case "prefix":
-		mode = VanityModePrefix
+		return VanityModePrefix, nil
 	case "suffix":
-		mode = VanityModeSuffix
+		return VanityModeSuffix, nil
 	case "prefix-or-suffix":
-		mode = VanityModePrefixOrSuffix
+		return VanityModePrefixOrSuffix, nil
 	case "prefix-and-suffix":
-		mode = VanityModePrefixAndSuffix
+		return VanityModePrefixAndSuffix, nil
 	default:
-		return nil, fmt.Errorf("mode must be one of: prefix, suffix, prefix-or-suffix, prefix-and-suffix")
+		return 0, fmt.Errorf("mode must be one of: prefix, suffix, prefix-or-suffix, prefix-and-suffix")
 	}
+}
 
-	var caseSensitive, upperOrLower bool
+func parseVanityCase(caseStr string) (caseSensitive, upperOrLower bool, err error) {
 	switch caseStr {
 	case "sensitive":
-		caseSensitive = true
-		upperOrLower = false
+		return true, false, nil
 	case "insensitive":
-		caseSensitive = false
-		upperOrLower = false
+		return false, false, nil
 	case "either":
-		caseSensitive = true
-		upperOrLower = true
+		return true, true, nil
 	default:
-		return nil, fmt.Errorf("case must be one of: sensitive, insensitive, either")
+		return false, false, fmt.Errorf("case must be one of: sensitive, insensitive, either")
 	}
+}
 
-	var threads int
-	if threadsStr == "auto" {
-		threads = runtime.NumCPU()
-	} else {
-		var err error
-		if _, err = fmt.Sscanf(threadsStr, "%d", &threads); err != nil || threads < 1 {
-			return nil, fmt.Errorf("threads must be a positive integer or 'auto'")
+// ContainsList splits Contains into its comma-separated alternatives.
+func (c *VanityConfig) ContainsList() []string {
+	var list []string
+	for _, pattern := range strings.Split(c.Contains, ",") {
+		if pattern != "" {
+			list = append(list, pattern)
 		}
 	}
+	return list
+}
 
-	return &VanityConfig{
-		Contains:      contains,
-		Mode:          mode,
-		CaseSensitive: caseSensitive,
-		UpperOrLower:  upperOrLower,
-		Threads:       threads,
-		Mask:          mask,
-		MaskValue:     maskValue,
-	}, nil
+// zeroBytesTarget maps the CREATE2 --leading-zero-bytes / --zero-bytes-total
+// targets onto the matching scorer, stored into *score.
+func zeroBytesTarget(cmd *cli.Command, score *string) (int, error) {
+	leading, total := cmd.Int("leading-zero-bytes"), cmd.Int("zero-bytes-total")
+	if leading == 0 && total == 0 {
+		return 0, nil
+	}
+	if leading != 0 && total != 0 {
+		return 0, fmt.Errorf("--leading-zero-bytes and --zero-bytes-total are mutually exclusive")
+	}
+	if *score != "" {
+		return 0, fmt.Errorf("--leading-zero-bytes and --zero-bytes-total replace --score")
+	}
+	target := leading
+	*score = VanityScoreLeadingZeroBytes
+	if total != 0 {
+		target = total
+		*score = VanityScoreZeroBytes
+	}
+	if target < 1 || target > 20 {
+		return 0, fmt.Errorf("zero byte target must be between 1 and 20, got %d", target)
+	}
+	return target, nil
 }
@@ -0,0 +1,269 @@
+package model
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// VanityBenchTarget is a generator `nb vanity bench` can measure.
+type VanityBenchTarget struct {
+	Name string
+	New  func() (AddressGenerator, error)
+	// Configure applies the searcher options the real search uses
+	// (checksum, alphabet, ...), so matching costs and the difficulty
+	// estimate are the same as in the search.
+	Configure func(*VanitySearcher)
+	// Validate checks a --contains pattern the way the search does; targets
+	// that reject it are skipped.
+	Validate func(*VanityConfig) error
+}
+
+var vanityBenchTargets []VanityBenchTarget
+
+// RegisterVanityBenchTarget adds a generator to `nb vanity bench`. Chain
+// packages call it from init.
+func RegisterVanityBenchTarget(target VanityBenchTarget) {
+	vanityBenchTargets = append(vanityBenchTargets, target)
+}
+
+// VanityBenchTargets returns the registered generators sorted by name.
+func VanityBenchTargets() []VanityBenchTarget {
+	targets := slices.Clone(vanityBenchTargets)
+	slices.SortFunc(targets, func(a, b VanityBenchTarget) int { return strings.Compare(a.Name, b.Name) })
+	return targets
+}
+
+// VanityBenchConfig is what `nb vanity bench` measures.
+type VanityBenchConfig struct {
+	Generators []string      // names of registered targets; empty for all
+	Threads    []int         // thread counts to try, ascending
+	Duration   time.Duration // per generator and thread count
+	// Pattern, when set, gets an expected time-to-find for each generator at
+	// its recommended thread count.
+	Pattern *VanityConfig
+}
+
+// VanityBenchFlags returns the flags of `nb vanity bench`.
+func VanityBenchFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "generator",
+			Aliases: []string{"g"},
+			Usage:   "Generators to benchmark (default: all registered).",
+		},
+		&cli.StringFlag{
+			Name:    "threads",
+			Aliases: []string{"t"},
+			Usage:   "Comma-separated thread counts to try (default: powers of two up to the CPU count, and the CPU count).",
+		},
+		&cli.DurationFlag{
+			Name:    "duration",
+			Aliases: []string{"d"},
+			Usage:   "How long to run each generator at each thread count.",
+			Value:   3 * time.Second,
+		},
+		&cli.StringFlag{
+			Name:    "contains",
+			Aliases: []string{"c"},
+			Usage:   "Estimate the time to find this pattern (comma-separated alternatives allowed).",
+		},
+		&cli.StringFlag{
+			Name:    "mode",
+			Aliases: []string{"m"},
+			Usage:   "Matching position for --contains: prefix, suffix, prefix-or-suffix, prefix-and-suffix.",
+			Value:   "prefix-or-suffix",
+		},
+		&cli.StringFlag{
+			Name:  "case",
+			Usage: "Case matching mode for --contains: sensitive, insensitive, either.",
+			Value: "sensitive",
+		},
+	}
+}
+
+func ParseVanityBenchConfig(cmd *cli.Command) (*VanityBenchConfig, error) {
+	config := &VanityBenchConfig{
+		Generators: cmd.StringSlice("generator"),
+		Duration:   cmd.Duration("duration"),
+	}
+	if config.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+	known := VanityBenchTargets()
+	for _, name := range config.Generators {
+		if !slices.ContainsFunc(known, func(t VanityBenchTarget) bool { return t.Name == name }) {
+			return nil, fmt.Errorf("unknown generator %q", name)
+		}
+	}
+
+	if s := cmd.String("threads"); s != "" {
+		for _, field := range strings.Split(s, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("threads must be comma-separated positive integers")
+			}
+			config.Threads = append(config.Threads, n)
+		}
+		slices.Sort(config.Threads)
+		config.Threads = slices.Compact(config.Threads)
+	} else {
+		config.Threads = defaultBenchThreads(runtime.NumCPU())
+	}
+
+	if contains := cmd.String("contains"); contains != "" {
+		mode, err := parseVanityMode(cmd.String("mode"))
+		if err != nil {
+			return nil, err
+		}
+		caseSensitive, upperOrLower, err := parseVanityCase(cmd.String("case"))
+		if err != nil {
+			return nil, err
+		}
+		config.Pattern = &VanityConfig{
+			Contains:      contains,
+			Mode:          mode,
+			CaseSensitive: caseSensitive,
+			UpperOrLower:  upperOrLower,
+		}
+	}
+	return config, nil
+}
+
+// defaultBenchThreads returns 1, 2, 4, ... up to cpus, plus cpus itself.
+func defaultBenchThreads(cpus int) []int {
+	var threads []int
+	for n := 1; n < cpus; n *= 2 {
+		threads = append(threads, n)
+	}
+	return append(threads, cpus)
+}
+
+// VanityBench measures each selected generator at each thread count and
+// logs its rate, the recommended thread count and, with a pattern, the
+// expected time to find it.
+func VanityBench(config *VanityBenchConfig) error {
+	var benched int
+	for _, target := range VanityBenchTargets() {
+		if len(config.Generators) > 0 && !slices.Contains(config.Generators, target.Name) {
+			continue
+		}
+		if config.Pattern != nil && target.Validate != nil {
+			if err := target.Validate(config.Pattern); err != nil {
+				log.Printf("%-8s skipped: %v", target.Name, err)
+				continue
+			}
+		}
+		benched++
+
+		rates := make([]float64, len(config.Threads))
+		for i, threads := range config.Threads {
+			// A fresh generator per run keeps the runs independent.
+			gen, err := target.New()
+			if err != nil {
+				return fmt.Errorf("%s: %w", target.Name, err)
+			}
+			searcher := newBenchSearcher(target, gen, config.Pattern)
+			if rates[i], err = searcher.Bench(threads, config.Duration); err != nil {
+				return fmt.Errorf("%s: %w", target.Name, err)
+			}
+			log.Printf("%-8s threads=%-3d %s/s (%s/s per thread)",
+				target.Name, threads, formatCount(rates[i]), formatCount(rates[i]/float64(threads)))
+		}
+
+		best := recommendBenchThreads(rates)
+		threads, rate := config.Threads[best], rates[best]
+		log.Printf("%-8s recommended threads: %d (%s/s)", target.Name, threads, formatCount(rate))
+
+		if config.Pattern != nil {
+			difficulty := newBenchSearcher(target, nil, config.Pattern).Difficulty()
+			if difficulty <= 0 {
+				log.Printf("%-8s no estimate for %q: outside this generator's alphabet", target.Name, config.Pattern.Contains)
+				continue
+			}
+			log.Printf("%-8s %q: %s attempts per match, expected %v per match, 50%% chance within %v",
+				target.Name, config.Pattern.Contains, formatCount(difficulty),
+				formatETA(difficulty/rate), formatETA(difficulty*math.Ln2/rate))
+		}
+	}
+	if benched == 0 && config.Pattern != nil {
+		return fmt.Errorf("%q is not a valid pattern for any of the generators", config.Pattern.Contains)
+	}
+	return nil
+}
+
+// newBenchSearcher wraps gen the way the target's search does. Without a
+// pattern, the matcher gets one that never matches, so every candidate pays
+// the full matching cost.
+func newBenchSearcher(target VanityBenchTarget, gen AddressGenerator, pattern *VanityConfig) *VanitySearcher {
+	if pattern == nil {
+		pattern = &VanityConfig{Contains: "zzzzzz", Mode: VanityModePrefixOrSuffix}
+	}
+	searcher := NewVanitySearcher(pattern, gen)
+	if target.Configure != nil {
+		target.Configure(searcher)
+	}
+	return searcher
+}
+
+// recommendBenchThreads returns the index of the smallest thread count whose
+// rate is within 5% of the best: past that point extra threads only add heat.
+func recommendBenchThreads(rates []float64) int {
+	best := slices.Max(rates)
+	for i, rate := range rates {
+		if rate >= best*0.95 {
+			return i
+		}
+	}
+	return len(rates) - 1
+}
+
+// benchBatch is how many candidates a bench worker tries between clock
+// reads.
+const benchBatch = 64
+
+// Bench runs the generator and matcher on threads workers for d, like a
+// search that never finds anything, and returns candidates per second.
+func (s *VanitySearcher) Bench(threads int, d time.Duration) (float64, error) {
+	var (
+		total    atomic.Uint64
+		errOnce  sync.Once
+		benchErr error
+		wg       sync.WaitGroup
+	)
+	start := time.Now()
+	deadline := start.Add(d)
+	for range threads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var n uint64
+			defer func() { total.Add(n) }()
+			for time.Now().Before(deadline) {
+				for range benchBatch {
+					address, _, err := s.generator.Generate()
+					if err != nil {
+						errOnce.Do(func() { benchErr = err })
+						return
+					}
+					s.matcher.Match(address)
+					n++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if benchErr != nil {
+		return 0, benchErr
+	}
+	return float64(total.Load()) / time.Since(start).Seconds(), nil
+}
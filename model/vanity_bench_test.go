@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type failingGenerator struct{}
+
+func (failingGenerator) Generate() (string, interface{}, error) {
+	return "", nil, errors.New("boom")
+}
+
+func TestVanitySearcher_Bench(t *testing.T) {
+	gen := &countingGenerator{}
+	searcher := NewVanitySearcher(&VanityConfig{Contains: "zzzz", Mode: VanityModePrefix}, gen)
+	rate, err := searcher.Bench(2, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := gen.counter.Load()
+	if rate <= 0 || generated == 0 || generated%benchBatch != 0 {
+		t.Fatalf("rate %f after %d candidates; want whole batches", rate, generated)
+	}
+
+	if _, err := NewVanitySearcher(&VanityConfig{}, failingGenerator{}).Bench(2, time.Second); err == nil {
+		t.Fatal("generator error not reported")
+	}
+}
+
+func TestRecommendBenchThreads(t *testing.T) {
+	tests := []struct {
+		rates []float64
+		want  int
+	}{
+		{[]float64{100}, 0},
+		{[]float64{100, 190, 380, 390}, 2}, // 380 is within 5% of 390
+		{[]float64{100, 190, 180, 170}, 1}, // oversubscribed: fewer threads win
+	}
+	for _, tt := range tests {
+		if got := recommendBenchThreads(tt.rates); got != tt.want {
+			t.Fatalf("recommendBenchThreads(%v) = %d, want %d", tt.rates, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultBenchThreads(t *testing.T) {
+	for cpus, want := range map[int][]int{
+		1:  {1},
+		4:  {1, 2, 4},
+		12: {1, 2, 4, 8, 12},
+	} {
+		if got := defaultBenchThreads(cpus); !slices.Equal(got, want) {
+			t.Fatalf("defaultBenchThreads(%d) = %v, want %v", cpus, got, want)
+		}
+	}
+}
+
+func TestVanityBench_SkipsInvalidPattern(t *testing.T) {
+	saved := vanityBenchTargets
+	t.Cleanup(func() { vanityBenchTargets = saved })
+
+	var hexRuns, base58Runs atomic.Int32
+	hexOnly := func(c *VanityConfig) error {
+		for _, char := range c.Contains {
+			if !strings.ContainsRune(HexAlphabet, char) {
+				return fmt.Errorf("contains illegal character: %c", char)
+			}
+		}
+		return nil
+	}
+	vanityBenchTargets = []VanityBenchTarget{
+		{Name: "hex", New: func() (AddressGenerator, error) { hexRuns.Add(1); return &countingGenerator{}, nil }, Validate: hexOnly},
+		{Name: "base58", New: func() (AddressGenerator, error) { base58Runs.Add(1); return &countingGenerator{}, nil }},
+	}
+	config := &VanityBenchConfig{Threads: []int{1}, Duration: 10 * time.Millisecond,
+		Pattern: &VanityConfig{Contains: "zzzz", Mode: VanityModePrefix, CaseSensitive: true}}
+
+	if err := VanityBench(config); err != nil {
+		t.Fatal(err)
+	}
+	if hexRuns.Load() != 0 || base58Runs.Load() == 0 {
+		t.Errorf("hex ran %d times, base58 %d; want only base58", hexRuns.Load(), base58Runs.Load())
+	}
+
+	config.Generators = []string{"hex"}
+	if err := VanityBench(config); err == nil {
+		t.Error("no error with every generator skipped")
+	}
+}
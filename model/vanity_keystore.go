@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// KeystorePassphrase resolves the passphrase for encrypted key files: from
+// --passphrase-file, then $NB_KEYSTORE_PASSPHRASE, then an interactive prompt
+// (asked twice). Chains call it before searching so a long run never ends at
+// an unattended prompt. The result is cached on the config.
+func (c *VanityConfig) KeystorePassphrase() (string, error) {
+	if c.passphrase != "" {
+		return c.passphrase, nil
+	}
+	var passphrase string
+	switch {
+	case c.PassphraseFile != "":
+		b, err := os.ReadFile(c.PassphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		passphrase = strings.TrimRight(string(b), "\r\n")
+	case os.Getenv("NB_KEYSTORE_PASSPHRASE") != "":
+		passphrase = os.Getenv("NB_KEYSTORE_PASSPHRASE")
+	default:
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return "", errors.New("keystore passphrase required: use --passphrase-file or NB_KEYSTORE_PASSPHRASE")
+		}
+		fmt.Fprint(os.Stderr, "Keystore passphrase: ")
+		first, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		second, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(first) != string(second) {
+			return "", errors.New("passphrases do not match")
+		}
+		passphrase = string(first)
+	}
+	if passphrase == "" {
+		return "", errors.New("keystore passphrase must not be empty")
+	}
+	c.passphrase = passphrase
+	return passphrase, nil
+}
+
+// PlaintextKeystore is for chains whose --keystore files hold the key in the
+// form their wallets import, unencrypted: it rejects --passphrase-file, which
+// would suggest otherwise, and says so in the log.
+func (c *VanityConfig) PlaintextKeystore(format string) error {
+	if c.PassphraseFile != "" {
+		return fmt.Errorf("--passphrase-file does not apply: --keystore writes %s files in plaintext", format)
+	}
+	if c.KeystoreDir != "" {
+		log.Printf("NOTE: --keystore writes %s files in plaintext (mode 0600), not an encrypted keystore", format)
+	}
+	return nil
+}
+
+// WriteSecretFile writes data to dir/name with 0600 permissions, creating dir
+// (0700) if needed. It refuses to overwrite an existing file so a key can
+// never be silently lost.
+func WriteSecretFile(dir, name string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
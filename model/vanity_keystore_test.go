@@ -0,0 +1,41 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVanityConfig_KeystorePassphraseFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pass")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &VanityConfig{PassphraseFile: path}
+	got, err := cfg.KeystorePassphrase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "s3cret" {
+		t.Fatalf("passphrase = %q, want trailing newline trimmed", got)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&VanityConfig{PassphraseFile: empty}).KeystorePassphrase(); err == nil {
+		t.Fatal("expected empty passphrase to be rejected")
+	}
+}
+
+func TestVanityConfig_PlaintextKeystore(t *testing.T) {
+	if err := (&VanityConfig{KeystoreDir: t.TempDir()}).PlaintextKeystore("WIF"); err != nil {
+		t.Errorf("keystore without passphrase: %v", err)
+	}
+	config := &VanityConfig{KeystoreDir: t.TempDir(), PassphraseFile: "pass.txt"}
+	if err := config.PlaintextKeystore("WIF"); err == nil || !strings.Contains(err.Error(), "plaintext") {
+		t.Errorf("--passphrase-file: err = %v, want a plaintext error", err)
+	}
+}
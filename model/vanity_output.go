@@ -0,0 +1,66 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+)
+
+// Result formats accepted by --output.
+const (
+	VanityOutputText = "text"
+	VanityOutputJSON = "json"
+)
+
+// vanityOutput is where JSON records go; a var so tests can capture it.
+var vanityOutput io.Writer = os.Stdout
+
+// VanityRecord is the machine-readable form of one vanity result. Fields that
+// don't apply to a chain or mode are omitted.
+type VanityRecord struct {
+	Address      string   `json:"address"`
+	PrivateKey   string   `json:"private_key,omitempty"`
+	PartialKey   string   `json:"partial_private_key,omitempty"` // set instead of PrivateKey with --split-key
+	Deployer     string   `json:"deployer,omitempty"`
+	Nonce        *uint64  `json:"nonce,omitempty"`         // CREATE1 deployer nonce
+	Seeds        []string `json:"seeds,omitempty"`         // Solana PDA seeds, without the bump
+	Bump         *uint8   `json:"bump,omitempty"`          // Solana PDA canonical bump
+	Base         string   `json:"base,omitempty"`          // Solana CreateAccountWithSeed base pubkey
+	Owner        string   `json:"owner,omitempty"`         // Solana CreateAccountWithSeed owner program
+	Seed         string   `json:"seed,omitempty"`          // Solana CreateAccountWithSeed seed
+	Mnemonic     string   `json:"mnemonic,omitempty"`      // BIP-39 phrase, set instead of PrivateKey with --mnemonic
+	Path         string   `json:"path,omitempty"`          // BIP-44 derivation path of the mnemonic's account
+	Proxy        string   `json:"proxy,omitempty"`         // CREATE3 proxy
+	Salt         string   `json:"salt,omitempty"`          // bytes32 as passed to the factory
+	SaltPreimage string   `json:"salt_preimage,omitempty"` // string hashed into Salt
+	KeyFile      string   `json:"key_file,omitempty"`      // set instead of PrivateKey with --keystore
+	Score        int      `json:"score,omitempty"`         // set with --score
+	Attempts     uint64   `json:"attempts"`
+	Duration     float64  `json:"duration_seconds"`
+}
+
+// NewVanityRecord starts a record for result with the search statistics
+// filled in; callers add the chain-specific fields.
+func NewVanityRecord(address string, result *VanityResult) *VanityRecord {
+	return &VanityRecord{
+		Address:  address,
+		Score:    result.Score,
+		Attempts: result.Attempts,
+		Duration: result.Elapsed.Seconds(),
+	}
+}
+
+// ReportVanityResult prints one result. With --output json it writes record
+// as a single JSON line to stdout; otherwise it calls logText, which logs the
+// chain's human-readable lines (to stderr, like every other log line).
+func ReportVanityResult(config *VanityConfig, record *VanityRecord, logText func()) error {
+	if config.Output != VanityOutputJSON {
+		if record.Score > 0 {
+			log.Printf("New best score: %d", record.Score)
+		}
+		logText()
+		return nil
+	}
+	return json.NewEncoder(vanityOutput).Encode(record)
+}
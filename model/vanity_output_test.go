@@ -0,0 +1,45 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReportVanityResult(t *testing.T) {
+	var buf bytes.Buffer
+	vanityOutput = &buf
+	defer func() { vanityOutput = os.Stdout }()
+
+	result := &VanityResult{Attempts: 1234, Elapsed: 1500 * time.Millisecond}
+	record := NewVanityRecord("0xdead", result)
+	record.PrivateKey = "0x01"
+
+	var logged bool
+	if err := ReportVanityResult(&VanityConfig{Output: VanityOutputText}, record, func() { logged = true }); err != nil {
+		t.Fatal(err)
+	}
+	if !logged || buf.Len() != 0 {
+		t.Fatalf("text mode: logged=%v stdout=%q, want logged and empty stdout", logged, buf.String())
+	}
+
+	logged = false
+	if err := ReportVanityResult(&VanityConfig{Output: VanityOutputJSON}, record, func() { logged = true }); err != nil {
+		t.Fatal(err)
+	}
+	if logged {
+		t.Fatal("json mode must not log the human-readable lines")
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not one JSON object: %q", buf.String())
+	}
+	if got["address"] != "0xdead" || got["private_key"] != "0x01" || got["attempts"] != float64(1234) || got["duration_seconds"] != 1.5 {
+		t.Fatalf("unexpected record: %v", got)
+	}
+	if _, ok := got["salt"]; ok {
+		t.Fatal("empty fields must be omitted")
+	}
+}
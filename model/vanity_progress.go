@@ -0,0 +1,222 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// Alphabets the searcher uses to estimate match difficulty.
+const (
+	HexAlphabet    = "0123456789abcdef"
+	Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// Difficulty estimates the expected number of attempts per match, assuming
+// every address character is uniform over alphabet. With a checksum function
+// wired in, the alphabet is lowercase hex and each letter of a case-sensitive
+// pattern costs another factor of two (EIP-55 uppercases ~half of letters).
+// Alternatives combine as independent events. Returns 0 when no estimate is
+// possible (unknown alphabet, impossible pattern, or a regex).
+func (m *VanityMatcher) Difficulty(alphabet string) float64 {
+	if m.regex != nil {
+		return 0
+	}
+	p := 1.0
+	if len(m.patterns) > 0 {
+		if m.checksumFn == nil && alphabet == "" {
+			return 0
+		}
+		miss := 1.0
+		for i := range m.patterns {
+			var window float64
+			if m.checksumFn != nil {
+				window = m.hexWindowProbability(&m.patterns[i])
+			} else {
+				window = m.windowProbability(&m.patterns[i], alphabet)
+			}
+			switch m.config.Mode {
+			case VanityModePrefixOrSuffix:
+				window = 2*window - window*window
+			case VanityModePrefixAndSuffix:
+				window *= window
+			}
+			miss *= 1 - window
+		}
+		p *= 1 - miss
+	}
+	for _, b := range m.config.Mask {
+		p /= float64(uint64(1) << bits.OnesCount8(b))
+	}
+	if p <= 0 {
+		return 0
+	}
+	return 1 / p
+}
+
+func (m *VanityMatcher) hexWindowProbability(pattern *vanityPattern) float64 {
+	p := math.Pow(1.0/16, float64(len(pattern.contains)))
+	letters := 0
+	for _, c := range pattern.lower {
+		if c >= 'a' && c <= 'f' {
+			letters++
+		}
+	}
+	switch {
+	case !m.config.CaseSensitive:
+	case m.config.UpperOrLower:
+		if letters > 0 {
+			p *= math.Pow(0.5, float64(letters-1))
+		}
+	default:
+		p *= math.Pow(0.5, float64(letters))
+	}
+	return p
+}
+
+func (m *VanityMatcher) windowProbability(pattern *vanityPattern, alphabet string) float64 {
+	if m.fixedPrefix != "" && m.config.Mode == VanityModePrefix &&
+		len(pattern.contains) >= len(m.fixedPrefix) && strings.EqualFold(pattern.contains[:len(m.fixedPrefix)], m.fixedPrefix) {
+		stripped := vanityPattern{
+			contains: pattern.contains[len(m.fixedPrefix):],
+			lower:    pattern.lower[len(m.fixedPrefix):],
+			upper:    pattern.upper[len(m.fixedPrefix):],
+		}
+		pattern = &stripped
+	}
+	n := float64(len(alphabet))
+	charP := func(c rune, fold bool) float64 {
+		hits := 0
+		for _, a := range alphabet {
+			if a == c || (fold && strings.EqualFold(string(a), string(c))) {
+				hits++
+			}
+		}
+		return float64(hits) / n
+	}
+	switch {
+	case !m.config.CaseSensitive || m.lowercase:
+		p := 1.0
+		for _, c := range pattern.contains {
+			p *= charP(c, true)
+		}
+		return p
+	case m.config.UpperOrLower:
+		lower, upper := 1.0, 1.0
+		for _, c := range pattern.lower {
+			lower *= charP(c, false)
+		}
+		for _, c := range pattern.upper {
+			upper *= charP(c, false)
+		}
+		if pattern.lower == pattern.upper {
+			return lower
+		}
+		return lower + upper
+	default:
+		p := 1.0
+		for _, c := range pattern.contains {
+			p *= charP(c, false)
+		}
+		return p
+	}
+}
+
+func (s *VanitySearcher) progressLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Progress)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.logProgress("Progress")
+		}
+	}
+}
+
+// logProgress prints one status line: throughput, totals, and — when the
+// difficulty is known — the chance that at least one match should have turned
+// up by now, assuming independent attempts (1 - e^(-attempts/difficulty)).
+func (s *VanitySearcher) logProgress(label string) {
+	runAttempts := s.attempts.Load()
+	runElapsed := time.Since(s.started)
+	total, elapsed := s.stats()
+
+	var rate float64
+	if runElapsed > 0 {
+		rate = float64(runAttempts) / runElapsed.Seconds()
+	}
+	threads := max(s.config.Threads, 1)
+
+	line := fmt.Sprintf("%s: %s attempts, %s/s (%s/s per thread), elapsed %v, found %d",
+		label, formatCount(float64(total)), formatCount(rate), formatCount(rate/float64(threads)),
+		elapsed.Round(time.Second), s.found.Load())
+	if s.config.Scorer != nil {
+		line += fmt.Sprintf(", best score %d", s.best.Load())
+	}
+
+	if difficulty := s.Difficulty(); difficulty > 0 {
+		probability := -math.Expm1(-float64(total) / difficulty)
+		line += fmt.Sprintf(", P(match by now) %.2f%%", probability*100)
+		if rate > 0 {
+			line += fmt.Sprintf(", expected %v per match", formatETA(difficulty/rate))
+			if half := difficulty * math.Ln2; float64(total) < half {
+				line += fmt.Sprintf(", 50%% ETA %v", formatETA((half-float64(total))/rate))
+			}
+		}
+	}
+	log.Print(line)
+}
+
+// Difficulty is the expected number of attempts per match, given the
+// searcher's alphabet and candidates per attempt; 0 when unknown.
+func (s *VanitySearcher) Difficulty() float64 {
+	difficulty := s.matcher.Difficulty(s.alphabet)
+	if s.candidates > 1 {
+		difficulty /= float64(s.candidates)
+	}
+	return difficulty
+}
+
+// LogEstimate logs the expected attempts per match and, assuming
+// perThreadRate attempts per second on each thread, the expected time to a
+// match. For generators far slower than raw keys, whose first progress line
+// would otherwise be the first hint of how long a pattern takes.
+func (s *VanitySearcher) LogEstimate(perThreadRate float64) {
+	difficulty := s.Difficulty()
+	if difficulty <= 0 {
+		return
+	}
+	rate := perThreadRate * float64(max(s.config.Threads, 1))
+	log.Printf("Estimate: %s attempts per match, expected %v at ~%s/s (%s/s per thread)",
+		formatCount(difficulty), formatETA(difficulty/rate), formatCount(rate), formatCount(perThreadRate))
+}
+
+// formatCount renders n with a K/M/G/T/P suffix.
+func formatCount(n float64) string {
+	const units = "KMGTP"
+	if n < 1000 {
+		return fmt.Sprintf("%.0f", n)
+	}
+	unit := -1
+	for n >= 1000 && unit < len(units)-1 {
+		n /= 1000
+		unit++
+	}
+	return fmt.Sprintf("%.2f%c", n, units[unit])
+}
+
+// formatETA renders a duration in seconds, capped at 100 years like
+// the up-front search estimates.
+func formatETA(seconds float64) string {
+	const hundredYears = 100 * 365 * 24 * 60 * 60
+	if seconds > hundredYears {
+		return "> 100 years"
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+}
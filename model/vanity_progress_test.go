@@ -0,0 +1,109 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVanityMatcher_Difficulty(t *testing.T) {
+	hexChecksum := func(lower string) string { return lower }
+	tests := []struct {
+		name     string
+		config   *VanityConfig
+		checksum ChecksumFunc
+		alphabet string
+		// bech32: single case, fixed "bc1q"
+		lowercase   bool
+		fixedPrefix string
+		want        float64
+	}{
+		{
+			name:     "hex insensitive prefix",
+			config:   &VanityConfig{Contains: "dead", Mode: VanityModePrefix},
+			checksum: hexChecksum,
+			want:     65536,
+		},
+		{
+			name:     "hex sensitive letters double per letter",
+			config:   &VanityConfig{Contains: "dE1", Mode: VanityModePrefix, CaseSensitive: true},
+			checksum: hexChecksum,
+			want:     4096 * 4,
+		},
+		{
+			name:     "hex either mode: one case choice is free",
+			config:   &VanityConfig{Contains: "abc", Mode: VanityModePrefix, CaseSensitive: true, UpperOrLower: true},
+			checksum: hexChecksum,
+			want:     4096 * 4,
+		},
+		{
+			name:     "hex prefix-and-suffix squares",
+			config:   &VanityConfig{Contains: "00", Mode: VanityModePrefixAndSuffix},
+			checksum: hexChecksum,
+			want:     65536,
+		},
+		{
+			name:     "mask only counts set bits",
+			config:   &VanityConfig{Mask: []byte{0xff, 0x0f}, MaskValue: []byte{0, 0}},
+			checksum: hexChecksum,
+			want:     4096,
+		},
+		{
+			name:     "base58 sensitive",
+			config:   &VanityConfig{Contains: "So", Mode: VanityModePrefix, CaseSensitive: true},
+			alphabet: Base58Alphabet,
+			want:     58 * 58,
+		},
+		{
+			name:     "base58 insensitive counts both cases",
+			config:   &VanityConfig{Contains: "a", Mode: VanityModePrefix},
+			alphabet: Base58Alphabet,
+			want:     29,
+		},
+		{
+			name:     "base58 character outside alphabet is impossible",
+			config:   &VanityConfig{Contains: "0", Mode: VanityModePrefix, CaseSensitive: true},
+			alphabet: Base58Alphabet,
+			want:     0,
+		},
+		{
+			name:        "bech32 ignores case and the fixed prefix",
+			config:      &VanityConfig{Contains: "bc1qDEAD", Mode: VanityModePrefix, CaseSensitive: true},
+			alphabet:    "qpzry9x8gf2tvdw0s3jn54khce6mua7l",
+			lowercase:   true,
+			fixedPrefix: "bc1q",
+			want:        1 << 20,
+		},
+		{
+			name:   "unknown alphabet",
+			config: &VanityConfig{Contains: "abc", Mode: VanityModePrefix, CaseSensitive: true},
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewVanityMatcher(tt.config)
+			m.checksumFn = tt.checksum
+			m.lowercase = tt.lowercase
+			m.fixedPrefix = tt.fixedPrefix
+			got := m.Difficulty(tt.alphabet)
+			if math.Abs(got-tt.want) > tt.want*1e-9 {
+				t.Fatalf("Difficulty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[float64]string{
+		0:       "0",
+		999:     "999",
+		1500:    "1.50K",
+		2500000: "2.50M",
+		3e18:    "3000.00P",
+	}
+	for in, want := range tests {
+		if got := formatCount(in); got != want {
+			t.Errorf("formatCount(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// repeatedCharIdiom matches the one backreference form worth supporting:
+// "(.)\1{5}", "(.)\1+" and friends — a run of one repeated character.
+var repeatedCharIdiom = regexp.MustCompile(`\(\.\)\\1(\{\d+(?:,\d*)?\}|[+*?])?`)
+
+var backreference = regexp.MustCompile(`\\[1-9]`)
+
+// regexRunAlphabet is what "(.)" is expanded over. It covers every character
+// a hex, base58 or bech32 address can contain.
+const regexRunAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// compileVanityRegex compiles expr plus a case-insensitive twin used as the
+// lowercase prefilter. Go's RE2 engine has no backreferences, so the
+// repeated-character idiom "(.)\1{n}" is expanded into an alternation over
+// the address alphabet; any other backreference is rejected.
+func compileVanityRegex(expr string) (exact, fold *regexp.Regexp, err error) {
+	expanded := expr
+	if loc := repeatedCharIdiom.FindStringIndex(expr); loc != nil && !strings.Contains(expr[:loc[0]], "(") {
+		idiom := repeatedCharIdiom.FindStringSubmatch(expr[loc[0]:loc[1]])
+		alternatives := make([]string, 0, len(regexRunAlphabet))
+		for _, c := range regexRunAlphabet {
+			ch := regexp.QuoteMeta(string(c))
+			alternatives = append(alternatives, ch+ch+idiom[1])
+		}
+		expanded = expr[:loc[0]] + "(?:" + strings.Join(alternatives, "|") + ")" + expr[loc[1]:]
+	}
+	if backreference.MatchString(expanded) {
+		return nil, nil, fmt.Errorf("invalid --regex %q: backreferences are not supported (only the (.)\\1{n} repeat idiom as the first group)", expr)
+	}
+	if exact, err = regexp.Compile(expanded); err != nil {
+		return nil, nil, fmt.Errorf("invalid --regex %q: %w", expr, err)
+	}
+	fold = regexp.MustCompile("(?i)" + expanded)
+	return exact, fold, nil
+}
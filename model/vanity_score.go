@@ -0,0 +1,164 @@
+package model
+
+import "fmt"
+
+// Built-in scorers accepted by --score.
+const (
+	VanityScoreLeadingZeros     = "leading-zeros"
+	VanityScoreLeadingZeroBytes = "leading-zero-bytes"
+	VanityScoreZeroBytes        = "zero-bytes"
+	VanityScoreRepeat           = "repeat"
+	VanityScorePattern          = "pattern"
+)
+
+// VanityScorer rates a candidate address for best-so-far searches; higher is
+// better. It is the graded counterpart of VanityMatcher.Match: with
+// VanityConfig.Scorer set, the searcher reports every strict improvement
+// instead of stopping at the first match.
+type VanityScorer interface {
+	Score(address string) int
+}
+
+// CaseAwareScorer is implemented by scorers whose result depends on letter
+// case. When the searcher has a checksum function and CaseAware reports true,
+// the scorer sees the display form (EIP-55) instead of the lowercase address.
+type CaseAwareScorer interface {
+	VanityScorer
+	CaseAware() bool
+}
+
+// VanityScoreFunc adapts a plain function to VanityScorer.
+type VanityScoreFunc func(address string) int
+
+func (f VanityScoreFunc) Score(address string) int { return f(address) }
+
+// NewVanityScorer returns the built-in scorer called name. The pattern scorer
+// reads its alternatives, mode and case handling from config.
+func NewVanityScorer(name string, config *VanityConfig) (VanityScorer, error) {
+	switch name {
+	case VanityScoreLeadingZeros:
+		return VanityScoreFunc(leadingZeros), nil
+	case VanityScoreLeadingZeroBytes:
+		return VanityScoreFunc(leadingZeroBytes), nil
+	case VanityScoreZeroBytes:
+		return VanityScoreFunc(zeroBytes), nil
+	case VanityScoreRepeat:
+		return VanityScoreFunc(longestRun), nil
+	case VanityScorePattern:
+		patterns := config.ContainsList()
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("--score pattern requires --contains")
+		}
+		return &patternScorer{patterns: patterns, mode: config.Mode, caseSensitive: config.CaseSensitive}, nil
+	default:
+		return nil, fmt.Errorf("score must be one of: %s, %s, %s, %s, %s",
+			VanityScoreLeadingZeros, VanityScoreLeadingZeroBytes, VanityScoreZeroBytes, VanityScoreRepeat, VanityScorePattern)
+	}
+}
+
+// checkScorerAlphabet rejects the zero-counting scorers outside hex, where a
+// '0' is neither a zero nibble nor part of a zero byte.
+func checkScorerAlphabet(name, alphabet string) error {
+	switch name {
+	case VanityScoreLeadingZeros, VanityScoreLeadingZeroBytes, VanityScoreZeroBytes:
+		if alphabet != HexAlphabet {
+			return fmt.Errorf("--score %s only applies to hex addresses; use %s or %s", name, VanityScoreRepeat, VanityScorePattern)
+		}
+	}
+	return nil
+}
+
+// leadingZeros counts leading '0' characters: zero nibbles for hex
+// addresses, which make calldata cheaper.
+func leadingZeros(address string) int {
+	n := 0
+	for n < len(address) && address[n] == '0' {
+		n++
+	}
+	return n
+}
+
+// leadingZeroBytes counts whole leading zero bytes of a hex address.
+func leadingZeroBytes(address string) int {
+	return leadingZeros(address) / 2
+}
+
+// zeroBytes counts zero bytes anywhere in a hex address; each one is
+// cheaper in calldata (4 gas instead of 16).
+func zeroBytes(address string) int {
+	n := 0
+	for i := 0; i+1 < len(address); i += 2 {
+		if address[i] == '0' && address[i+1] == '0' {
+			n++
+		}
+	}
+	return n
+}
+
+// longestRun returns the length of the longest run of one repeated
+// character, ignoring case.
+func longestRun(address string) int {
+	best, run := 0, 0
+	for i := 0; i < len(address); i++ {
+		if i > 0 && lowerASCII(address[i]) == lowerASCII(address[i-1]) {
+			run++
+		} else {
+			run = 1
+		}
+		best = max(best, run)
+	}
+	return best
+}
+
+// patternScorer counts characters that sit where one of the --contains
+// alternatives wants them, at the position(s) selected by the mode.
+type patternScorer struct {
+	patterns      []string
+	mode          VanityMode
+	caseSensitive bool
+}
+
+func (p *patternScorer) CaseAware() bool { return p.caseSensitive }
+
+func (p *patternScorer) Score(address string) int {
+	best := 0
+	for _, pattern := range p.patterns {
+		prefix := p.hits(pattern, address, 0)
+		suffix := p.hits(pattern, address, len(address)-len(pattern))
+		var score int
+		switch p.mode {
+		case VanityModePrefix:
+			score = prefix
+		case VanityModeSuffix:
+			score = suffix
+		case VanityModePrefixAndSuffix:
+			score = prefix + suffix
+		default:
+			score = max(prefix, suffix)
+		}
+		best = max(best, score)
+	}
+	return best
+}
+
+// hits counts positions where address[offset+i] equals pattern[i].
+func (p *patternScorer) hits(pattern, address string, offset int) int {
+	if offset < 0 || offset+len(pattern) > len(address) {
+		return 0
+	}
+	n := 0
+	for i := 0; i < len(pattern); i++ {
+		a, b := address[offset+i], pattern[i]
+		if a == b || (!p.caseSensitive && lowerASCII(a) == lowerASCII(b)) {
+			n++
+		}
+	}
+	return n
+}
+
+func lowerASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
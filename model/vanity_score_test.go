@@ -0,0 +1,160 @@
+package model
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestVanityScorers(t *testing.T) {
+	pattern := func(contains string, mode VanityMode, cs bool) VanityScorer {
+		s, err := NewVanityScorer(VanityScorePattern, &VanityConfig{Contains: contains, Mode: mode, CaseSensitive: cs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	tests := []struct {
+		name    string
+		scorer  VanityScorer
+		address string
+		want    int
+	}{
+		{"leading zeros", VanityScoreFunc(leadingZeros), "000a00", 3},
+		{"leading zeros none", VanityScoreFunc(leadingZeros), "a000", 0},
+		{"leading zero bytes rounds down", VanityScoreFunc(leadingZeroBytes), "00000a00", 2},
+		{"zero bytes are byte-aligned", VanityScoreFunc(zeroBytes), "00a00b0000", 3},
+		{"repeat run", VanityScoreFunc(longestRun), "ab7777c77", 4},
+		{"repeat run ignores case", VanityScoreFunc(longestRun), "xaAaAy", 4},
+		{"pattern prefix counts characters in place", pattern("dead", VanityModePrefix, true), "dxad1234", 3},
+		{"pattern suffix", pattern("beef", VanityModeSuffix, true), "1234beaf", 3},
+		{"pattern either end takes the better", pattern("beef", VanityModePrefixOrSuffix, true), "be00beaf", 3},
+		{"pattern both ends add up", pattern("ab", VanityModePrefixAndSuffix, true), "ab1234ab", 4},
+		{"pattern best alternative", pattern("0000,ffff", VanityModePrefix, true), "ff0fxxxx", 3},
+		{"pattern case-sensitive", pattern("DEAD", VanityModePrefix, true), "dead", 0},
+		{"pattern case-insensitive", pattern("DEAD", VanityModePrefix, false), "dead", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scorer.Score(tt.address); got != tt.want {
+				t.Fatalf("Score(%q) = %d, want %d", tt.address, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewVanityScorer(VanityScorePattern, &VanityConfig{}); err == nil {
+		t.Error("pattern scorer without --contains should be rejected")
+	}
+	if _, err := NewVanityScorer("nope", &VanityConfig{}); err == nil {
+		t.Error("unknown scorer should be rejected")
+	}
+}
+
+// TestVanitySearcher_ScoreStreamsImprovements scores the counter's last digit:
+// within 100 attempts exactly the improvements 1..9 must be reported, in order.
+func TestVanitySearcher_ScoreStreamsImprovements(t *testing.T) {
+	cfg := &VanityConfig{
+		Threads:     1,
+		MaxAttempts: 100,
+		Scorer: VanityScoreFunc(func(address string) int {
+			n, _ := strconv.Atoi(address)
+			return n % 10
+		}),
+	}
+	var scores []int
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		scores = append(scores, r.Score)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 9 {
+		t.Fatalf("got improvements %v, want 1..9", scores)
+	}
+	for i, score := range scores {
+		if score != i+1 {
+			t.Fatalf("got improvements %v, want 1..9", scores)
+		}
+	}
+}
+
+func TestVanitySearcher_ScoreBudgetIsNotAnError(t *testing.T) {
+	cfg := &VanityConfig{
+		Contains: "dead", // belongs to the scorer, must not filter
+		Threads:  4,
+		Budget:   50 * time.Millisecond,
+		Scorer:   VanityScoreFunc(longestRun),
+	}
+	var best int
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		if r.Score <= best {
+			t.Errorf("score %d reported after %d", r.Score, best)
+		}
+		best = r.Score
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchEach() = %v, want nil once the budget runs out", err)
+	}
+	if best != 8 {
+		t.Fatalf("best score = %d, want 8 (counter 00000000)", best)
+	}
+}
+
+func TestVanitySearcher_ScoreStopsAtTarget(t *testing.T) {
+	cfg := &VanityConfig{
+		Threads: 1,
+		Target:  5,
+		Scorer: VanityScoreFunc(func(address string) int {
+			n, _ := strconv.Atoi(address)
+			return n % 10
+		}),
+	}
+	var last *VanityResult
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		last = r
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last == nil || last.Score != 5 || last.Address != "00000005" {
+		t.Fatalf("last result = %+v, want score 5 at 00000005", last)
+	}
+}
+
+func TestParseVanityConfig_ScoreAlphabet(t *testing.T) {
+	tests := []struct {
+		score    string
+		alphabet string
+		wantErr  bool
+	}{
+		{VanityScoreLeadingZeros, HexAlphabet, false},
+		{VanityScoreZeroBytes, HexAlphabet, false},
+		{VanityScoreLeadingZeros, Base58Alphabet, true},
+		{VanityScoreLeadingZeroBytes, Base58Alphabet, true},
+		{VanityScoreZeroBytes, "qpzry9x8gf2tvdw0s3jn54khce6mua7l", true},
+		{VanityScoreRepeat, Base58Alphabet, false},
+	}
+	for _, tt := range tests {
+		var parseErr error
+		cmd := &cli.Command{
+			Name:  "vanity",
+			Flags: VanitySecpFlags(),
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				_, parseErr = ParseVanityConfig(cmd, tt.alphabet)
+				return nil
+			},
+		}
+		if err := cmd.Run(context.Background(), []string{"vanity", "--score", tt.score}); err != nil {
+			t.Fatal(err)
+		}
+		if (parseErr != nil) != tt.wantErr {
+			t.Errorf("--score %s on %q: err = %v, wantErr %v", tt.score, tt.alphabet, parseErr, tt.wantErr)
+		}
+	}
+}
@@ -3,7 +3,17 @@ package model
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/naiba/nb/internal"
 )
 
 // ChecksumFunc converts a lowercase address (e.g. hex for Ethereum) into its
@@ -17,21 +27,52 @@ type ChecksumFunc func(lowerHex string) string
 
 // VanityMatcher performs address matching based on configuration
 type VanityMatcher struct {
-	config        *VanityConfig
-	containsLower string
-	containsUpper string
-	checksumFn    ChecksumFunc
+	config     *VanityConfig
+	patterns   []vanityPattern
+	regex      *regexp.Regexp // nil unless config.Regex is set
+	regexFold  *regexp.Regexp // case-insensitive twin of regex, the lowercase prefilter
+	checksumFn ChecksumFunc
+
+	// lowercase marks encodings with a single case (bech32): there is no
+	// case to match, so patterns compare case-insensitively whatever --case
+	// says.
+	lowercase bool
+	// fixedPrefix is what every address starts with ("bc1q"); the difficulty
+	// estimate doesn't charge prefix patterns for it.
+	fixedPrefix string
+}
+
+// vanityPattern is one --contains alternative with its case variants
+// precomputed so the hot loop never allocates.
+type vanityPattern struct {
+	contains string
+	lower    string
+	upper    string
 }
 
-// NewVanityMatcher creates a new matcher with the given configuration
+// NewVanityMatcher creates a new matcher with the given configuration.
+// config.Regex must be valid (ParseVanityConfig checks it); an invalid
+// expression panics.
 func NewVanityMatcher(config *VanityConfig) *VanityMatcher {
-	return &VanityMatcher{
-		config:        config,
-		containsLower: strings.ToLower(config.Contains),
-		containsUpper: strings.ToUpper(config.Contains),
+	m := &VanityMatcher{config: config}
+	for _, contains := range config.ContainsList() {
+		m.patterns = append(m.patterns, vanityPattern{
+			contains: contains,
+			lower:    strings.ToLower(contains),
+			upper:    strings.ToUpper(contains),
+		})
 	}
+	if config.Regex != "" {
+		var err error
+		if m.regex, m.regexFold, err = compileVanityRegex(config.Regex); err != nil {
+			panic(err)
+		}
+	}
+	return m
 }
 
+// Match reports whether address satisfies every configured criterion: the
+// mask, the regex, and at least one of the --contains alternatives.
 func (m *VanityMatcher) Match(address string) bool {
 	if m.config.Mask != nil {
 		addrBytes, err := hex.DecodeString(address)
@@ -43,11 +84,29 @@ func (m *VanityMatcher) Match(address string) bool {
 				return false
 			}
 		}
-		if m.config.Contains == "" {
+	}
+
+	// Both are computed at most once per candidate, and only on demand.
+	var display, lower string
+
+	if m.regex != nil && !m.matchRegex(address, &display) {
+		return false
+	}
+	if len(m.patterns) == 0 {
+		return true
+	}
+	for i := range m.patterns {
+		if m.matchPattern(&m.patterns[i], address, &display, &lower) {
 			return true
 		}
 	}
+	return false
+}
 
+func (m *VanityMatcher) matchPattern(p *vanityPattern, address string, display, lower *string) bool {
+	if m.lowercase {
+		return m.matchesCriteria(p.lower, address)
+	}
 	// Fast path when the generator promised lowercase input + provided a
 	// checksum function. We can always match case-insensitively (directly,
 	// no ToLower copy), and only compute the checksum when we actually need
@@ -55,48 +114,76 @@ func (m *VanityMatcher) Match(address string) bool {
 	if m.checksumFn != nil {
 		switch {
 		case !m.config.CaseSensitive:
-			return m.matchesCriteria(m.containsLower, address)
+			return m.matchesCriteria(p.lower, address)
 		case m.config.UpperOrLower:
 			// "either" 语义：EIP-55 形式下匹配段必须全大写或全小写（非混合）。
 			// 先用 lowercase 预过滤（字母位必须命中目标字符），再拿 EIP-55 形式
-			// 分别与 containsLower / containsUpper 做字面比较——任一命中即证明
+			// 分别与 p.lower / p.upper 做字面比较——任一命中即证明
 			// 该段字母全为同一 case。纯数字的 contains 两侧都会命中，等价于
 			// 字面匹配，符合预期。
 			// 老代码里这个分支在 CS=true 时不可达（if/else if），事实等同 sensitive；
 			// 修正后 sensitive < either < insensitive 严格度单调。
-			if !m.matchesCriteria(m.containsLower, address) {
+			if !m.matchesCriteria(p.lower, address) {
 				return false
 			}
-			checksum := m.checksumFn(address)
-			return m.matchesCriteria(m.containsLower, checksum) ||
-				m.matchesCriteria(m.containsUpper, checksum)
+			checksum := m.display(address, display)
+			return m.matchesCriteria(p.lower, checksum) ||
+				m.matchesCriteria(p.upper, checksum)
 		default:
 			// CaseSensitive: the *displayed* (EIP-55) form must contain
-			// config.Contains verbatim. Do a cheap lowercase prefilter first,
+			// the pattern verbatim. Do a cheap lowercase prefilter first,
 			// then verify the checksum form on a hit. This applies equally to
 			// all-lowercase and mixed-case contains: for contains="abc" we
 			// must still reject addresses whose EIP-55 renders as "aBc", so
 			// the fast-path that skipped the checksum check for lowercase-only
 			// contains would silently accept false matches (see git history).
-			if !m.matchesCriteria(m.containsLower, address) {
+			if !m.matchesCriteria(p.lower, address) {
 				return false
 			}
-			return m.matchesCriteria(m.config.Contains, m.checksumFn(address))
+			return m.matchesCriteria(p.contains, m.display(address, display))
 		}
 	}
 
-	var passed bool
 	if m.config.CaseSensitive {
-		passed = m.matchesCriteria(m.config.Contains, address)
+		return m.matchesCriteria(p.contains, address)
 	} else if m.config.UpperOrLower {
-		passed = m.matchesCriteria(m.containsLower, address) ||
-			m.matchesCriteria(m.containsUpper, address)
-	} else {
-		addressLower := strings.ToLower(address)
-		passed = m.matchesCriteria(m.containsLower, addressLower)
+		return m.matchesCriteria(p.lower, address) ||
+			m.matchesCriteria(p.upper, address)
 	}
+	if *lower == "" {
+		*lower = strings.ToLower(address)
+	}
+	return m.matchesCriteria(p.lower, *lower)
+}
 
-	return passed
+// matchRegex mirrors the contains fast path: with a checksum function the
+// case-insensitive twin prefilters the lowercase address, and the checksum
+// form is only computed for case-sensitive verification of a hit.
+func (m *VanityMatcher) matchRegex(address string, display *string) bool {
+	if m.lowercase {
+		return m.regexFold.MatchString(address)
+	}
+	if m.checksumFn != nil {
+		if !m.regexFold.MatchString(address) {
+			return false
+		}
+		if !m.config.CaseSensitive {
+			return true
+		}
+		return m.regex.MatchString(m.display(address, display))
+	}
+	if !m.config.CaseSensitive {
+		return m.regexFold.MatchString(address)
+	}
+	return m.regex.MatchString(address)
+}
+
+// display returns the checksum form of address, computing it on first use.
+func (m *VanityMatcher) display(address string, cached *string) string {
+	if *cached == "" {
+		*cached = m.checksumFn(address)
+	}
+	return *cached
 }
 
 // matchesCriteria checks if address matches the contains string based on mode
@@ -132,13 +219,46 @@ type VanitySearcher struct {
 	config    *VanityConfig
 	matcher   *VanityMatcher
 	generator AddressGenerator
+
+	// attempts counts candidates generated in this run; workers flush into it
+	// in batches so the hot loop doesn't contend on a shared atomic.
+	attempts atomic.Uint64
+	found    atomic.Uint64
+	best     atomic.Int64 // best score reported so far, with a scorer
+	started  time.Time
+
+	// alphabet drives the difficulty estimate in progress lines; hex is
+	// implied when a checksum function is set.
+	alphabet string
+	// candidates is how many addresses one attempt tests (see
+	// WithCandidatesPerAttempt); 0 means one.
+	candidates int
+
+	// Carried over from a resumed state file.
+	resumedAttempts uint64
+	resumedElapsed  time.Duration
 }
 
-// NewVanitySearcher creates a new searcher
+// attemptsFlushInterval is how many candidates a worker tries before adding
+// its local count to the shared attempts counter.
+const attemptsFlushInterval = 1024
+
+// vanityCheckpointInterval is how often the state file is rewritten while a
+// search with VanityConfig.StatePath runs.
+const vanityCheckpointInterval = 30 * time.Second
+
+// NewVanitySearcher creates a new searcher. With config.Scorer set, Contains
+// belongs to the scorer, so the matcher only applies the mask and regex.
 func NewVanitySearcher(config *VanityConfig, generator AddressGenerator) *VanitySearcher {
+	matcherConfig := config
+	if config.Scorer != nil {
+		filters := *config
+		filters.Contains = ""
+		matcherConfig = &filters
+	}
 	return &VanitySearcher{
 		config:    config,
-		matcher:   NewVanityMatcher(config),
+		matcher:   NewVanityMatcher(matcherConfig),
 		generator: generator,
 	}
 }
@@ -147,7 +267,50 @@ func NewVanitySearcher(config *VanityConfig, generator AddressGenerator) *Vanity
 // generator's output as lowercase and compute the display form only on hits.
 // Chainable: returns the searcher.
 func (s *VanitySearcher) WithChecksum(fn ChecksumFunc) *VanitySearcher {
-	s.matcher.checksumFn = fn
+	s.matcher.WithChecksum(fn)
+	return s
+}
+
+// WithChecksum is the matcher-level variant, for callers that match without
+// a searcher. Chainable: returns the matcher.
+func (m *VanityMatcher) WithChecksum(fn ChecksumFunc) *VanityMatcher {
+	m.checksumFn = fn
+	return m
+}
+
+// WithLowercase declares that the generator emits single-case (lowercase)
+// addresses, such as bech32. Chainable: returns the searcher.
+func (s *VanitySearcher) WithLowercase() *VanitySearcher {
+	s.matcher.lowercase = true
+	return s
+}
+
+// WithFixedPrefix declares the prefix every address starts with, so the
+// difficulty estimate of a prefix pattern that spells it out only counts the
+// characters after it. Chainable: returns the searcher.
+func (s *VanitySearcher) WithFixedPrefix(prefix string) *VanitySearcher {
+	s.matcher.fixedPrefix = prefix
+	return s
+}
+
+// WithCandidatesPerAttempt tells progress reporting that each generated
+// address stands for n tested candidates, for generators that try several
+// addresses per key and return the best. Chainable: returns the searcher.
+func (s *VanitySearcher) WithCandidatesPerAttempt(n int) *VanitySearcher {
+	s.candidates = n
+	return s
+}
+
+// Matcher returns the matcher the workers apply, so generators that test
+// several candidates per attempt can pick the matching one.
+func (s *VanitySearcher) Matcher() *VanityMatcher {
+	return s.matcher
+}
+
+// WithAlphabet declares the address alphabet so progress reporting can
+// estimate how many attempts a match takes. Chainable: returns the searcher.
+func (s *VanitySearcher) WithAlphabet(alphabet string) *VanitySearcher {
+	s.alphabet = alphabet
 	return s
 }
 
@@ -155,48 +318,306 @@ func (s *VanitySearcher) WithChecksum(fn ChecksumFunc) *VanitySearcher {
 type VanityResult struct {
 	Address string
 	Data    interface{}
+	Score   int // set when searching with a VanityScorer
+
+	// Search statistics (including any resumed run) when the result was
+	// collected.
+	Attempts uint64
+	Elapsed  time.Duration
 }
 
-// Search performs the vanity address search
+// Search performs the vanity address search and returns the first match.
+// With VanityConfig.StatePath set, it first resumes from the state file (if
+// any) and checkpoints into it periodically, on completion, and when nb is
+// interrupted.
 func (s *VanitySearcher) Search(ctx context.Context) (*VanityResult, error) {
-	result := make(chan *VanityResult, 1)
+	var res *VanityResult
+	err := s.run(ctx, 1, func(r *VanityResult) error {
+		res = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SearchEach streams up to VanityConfig.Count distinct matches (at least one)
+// to fn as they are found, then stops. fn is called from a single goroutine,
+// so it may print without locking; a non-nil error from fn ends the search.
+//
+// With VanityConfig.Scorer set it instead streams each strictly better
+// candidate until the budget runs out or VanityConfig.Target is reached;
+// neither is an error.
+func (s *VanitySearcher) SearchEach(ctx context.Context, fn func(*VanityResult) error) error {
+	if s.config.Scorer == nil {
+		return s.run(ctx, max(s.config.Count, 1), fn)
+	}
+
+	budgetCtx := ctx
+	if s.config.Budget > 0 {
+		var cancel context.CancelFunc
+		budgetCtx, cancel = context.WithTimeout(ctx, s.config.Budget)
+		defer cancel()
+	}
+	err := s.run(budgetCtx, math.MaxInt, fn)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		err = nil
+	}
+	if err == nil {
+		if s.config.Target > 0 && s.best.Load() >= int64(s.config.Target) {
+			log.Printf("Target score %d reached", s.config.Target)
+		} else if s.found.Load() == 0 {
+			log.Printf("Budget exhausted without a candidate scoring above 0")
+		} else {
+			log.Printf("Budget exhausted, best score: %d", s.best.Load())
+		}
+	}
+	return err
+}
+
+func (s *VanitySearcher) run(ctx context.Context, limit int, fn func(*VanityResult) error) error {
+	if err := s.resume(); err != nil {
+		return err
+	}
+	s.started = time.Now()
+
+	results := make(chan *VanityResult)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Start worker goroutines
-	for i := 0; i < s.config.Threads; i++ {
+	// On Ctrl-C main exits right after the hooks return, so wait (bounded)
+	// for the workers to drain and the final checkpoint to land.
+	stopped := make(chan struct{})
+	unregister := internal.OnInterrupt(func() {
+		cancel()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer unregister()
+
+	var loops sync.WaitGroup
+	if s.config.StatePath != "" {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			s.checkpointLoop(ctx)
+		}()
+	}
+	if s.config.Progress > 0 {
+		loops.Add(1)
 		go func() {
+			defer loops.Done()
+			s.progressLoop(ctx)
+		}()
+	}
+
+	// Generators never repeat a counter position, but a resumed or
+	// overlapping run could, so workers dedup on the address before
+	// delivering; seen then counts exactly the matches the collector gets. A
+	// worker that delivers the last wanted match stops immediately, so the
+	// generator position (and any checkpoint) doesn't run past unreported
+	// matches. Scored results need no dedup: only strict improvements get
+	// through.
+	var seenMu sync.Mutex
+	seen := make(map[string]struct{})
+	var workers sync.WaitGroup
+	for i := 0; i < max(s.config.Threads, 1); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			var attempts uint64
+			defer func() { s.attempts.Add(attempts) }()
 			for {
 				select {
 				case <-ctx.Done():
 					return
 				default:
 					address, data, err := s.generator.Generate()
+					attempts++
+					if attempts == attemptsFlushInterval {
+						s.attempts.Add(attempts)
+						attempts = 0
+					}
 					if err != nil {
 						continue
 					}
 
+					if s.config.MaxAttempts > 0 && s.attempts.Load()+attempts >= s.config.MaxAttempts {
+						return
+					}
+
+					if s.config.Scorer != nil {
+						score := s.score(address)
+						if int64(score) <= s.best.Load() || !s.matcher.Match(address) {
+							continue
+						}
+						select {
+						case results <- &VanityResult{
+							Address: address,
+							Data:    data,
+							Score:   score,
+						}:
+						case <-ctx.Done():
+							return
+						}
+						continue
+					}
+
 					if s.matcher.Match(address) {
+						seenMu.Lock()
+						if len(seen) >= limit {
+							seenMu.Unlock()
+							return
+						}
+						if _, dup := seen[address]; dup {
+							seenMu.Unlock()
+							continue
+						}
+						seen[address] = struct{}{}
+						last := len(seen) == limit
+						seenMu.Unlock()
+
 						select {
-						case result <- &VanityResult{
+						case results <- &VanityResult{
 							Address: address,
 							Data:    data,
 						}:
-							cancel() // Notify other goroutines to exit
-						default: // Prevent deadlock
+						case <-ctx.Done():
+							return
+						}
+						if last {
+							return
 						}
-						return
 					}
 				}
 			}
 		}()
 	}
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
 
-	// Wait for result
-	select {
-	case res := <-result:
-		return res, nil
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	var err error
+	var collected int
+collect:
+	for collected < limit {
+		select {
+		case res := <-results:
+			if s.config.Scorer != nil {
+				// Workers race on the best score; settle it here.
+				if int64(res.Score) <= s.best.Load() {
+					continue
+				}
+				s.best.Store(int64(res.Score))
+			} else {
+				collected++
+			}
+			s.found.Add(1)
+			res.Attempts, res.Elapsed = s.stats()
+			if err = fn(res); err != nil {
+				break collect
+			}
+			if s.config.Target > 0 && res.Score >= s.config.Target {
+				break collect
+			}
+		case <-workersDone:
+			break collect
+		case <-ctx.Done():
+			err = ctx.Err()
+			break collect
+		}
+	}
+	cancel()
+	<-workersDone
+	loops.Wait()
+
+	if s.config.Progress > 0 {
+		s.logProgress("Summary")
+	}
+	if s.config.StatePath != "" {
+		if saveErr := s.saveState(); saveErr != nil {
+			log.Printf("WARNING: failed to save vanity state to %s: %v", s.config.StatePath, saveErr)
+		}
+	}
+	close(stopped)
+	return err
+}
+
+// score rates address, handing case-aware scorers the display form when a
+// checksum function is set.
+func (s *VanitySearcher) score(address string) int {
+	if s.matcher.checksumFn != nil {
+		if scorer, ok := s.config.Scorer.(CaseAwareScorer); ok && scorer.CaseAware() {
+			return scorer.Score(s.matcher.checksumFn(address))
+		}
+	}
+	return s.config.Scorer.Score(address)
+}
+
+// resume restores the generator from VanityConfig.StatePath if the file
+// exists, refusing state written by a different generator or pattern.
+func (s *VanitySearcher) resume() error {
+	if s.config.StatePath == "" {
+		return nil
+	}
+	gen, ok := s.generator.(ResumableGenerator)
+	if !ok {
+		return fmt.Errorf("%T does not support resumable searches", s.generator)
+	}
+	state, err := LoadVanityState(s.config.StatePath)
+	if err != nil || state == nil {
+		return err
+	}
+	if generator := fmt.Sprintf("%T", s.generator); state.Generator != generator {
+		return fmt.Errorf("state file %s was written by %s, not %s", s.config.StatePath, state.Generator, generator)
 	}
+	if state.Criteria != vanityCriteria(s.config) {
+		return fmt.Errorf("state file %s was written for a different pattern (%s)", s.config.StatePath, state.Criteria)
+	}
+	if err := gen.Restore(state.Checkpoint); err != nil {
+		return fmt.Errorf("failed to resume from %s: %w", s.config.StatePath, err)
+	}
+	s.resumedAttempts = state.Attempts
+	s.resumedElapsed = time.Duration(state.Elapsed * float64(time.Second))
+	log.Printf("Resuming from %s: %d attempts over %v so far", s.config.StatePath, state.Attempts, s.resumedElapsed.Round(time.Second))
+	return nil
+}
+
+func (s *VanitySearcher) checkpointLoop(ctx context.Context) {
+	ticker := time.NewTicker(vanityCheckpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.saveState(); err != nil {
+				log.Printf("WARNING: failed to save vanity state to %s: %v", s.config.StatePath, err)
+			}
+		}
+	}
+}
+
+// stats returns attempts and elapsed time including any resumed run.
+func (s *VanitySearcher) stats() (attempts uint64, elapsed time.Duration) {
+	return s.resumedAttempts + s.attempts.Load(), s.resumedElapsed + time.Since(s.started)
+}
+
+func (s *VanitySearcher) saveState() error {
+	// resume() already verified the generator is resumable.
+	gen := s.generator.(ResumableGenerator)
+	attempts, elapsed := s.stats()
+	return SaveVanityState(s.config.StatePath, &VanityState{
+		Generator:  fmt.Sprintf("%T", s.generator),
+		Criteria:   vanityCriteria(s.config),
+		Checkpoint: gen.Checkpoint(),
+		Attempts:   attempts,
+		Elapsed:    elapsed.Seconds(),
+		UpdatedAt:  time.Now(),
+	})
 }
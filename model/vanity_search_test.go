@@ -1,6 +1,12 @@
 package model
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -946,3 +952,226 @@ func BenchmarkVanityMatcher_Match(b *testing.B) {
 		})
 	}
 }
+
+func TestVanitySearcher_SearchEachStreamsCount(t *testing.T) {
+	cfg := &VanityConfig{
+		Contains:      "5",
+		Mode:          VanityModeSuffix,
+		CaseSensitive: true,
+		Threads:       1,
+		Count:         3,
+	}
+	var got []string
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		if r.Data.(uint64)%10 != 5 {
+			t.Fatalf("result data %v doesn't belong to address %s", r.Data, r.Address)
+		}
+		got = append(got, r.Address)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"00000005", "00000015", "00000025"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestVanitySearcher_SearchEachDistinctAcrossThreads(t *testing.T) {
+	cfg := &VanityConfig{
+		Contains:      "7",
+		Mode:          VanityModeSuffix,
+		CaseSensitive: true,
+		Threads:       8,
+		Count:         50,
+	}
+	seen := make(map[string]bool)
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		if seen[r.Address] {
+			t.Fatalf("%s reported twice", r.Address)
+		}
+		seen[r.Address] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 50 {
+		t.Fatalf("got %d results, want 50", len(seen))
+	}
+}
+
+// repeatingGenerator yields every address twice in a row.
+type repeatingGenerator struct {
+	counter atomic.Uint64
+}
+
+func (g *repeatingGenerator) Generate() (string, interface{}, error) {
+	c := (g.counter.Add(1) - 1) / 2
+	return fmt.Sprintf("%08d", c), c, nil
+}
+
+func TestVanitySearcher_SearchEachCountsAfterDedup(t *testing.T) {
+	for _, threads := range []int{1, 4} {
+		cfg := &VanityConfig{Contains: "5", Mode: VanityModeSuffix, CaseSensitive: true, Threads: threads, Count: 3}
+		var got []string
+		err := NewVanitySearcher(cfg, &repeatingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+			got = append(got, r.Address)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Sort(got); len(slices.Compact(got)) != 3 {
+			t.Errorf("threads=%d: got %v, want 3 distinct matches", threads, got)
+		}
+		if threads == 1 && !slices.Equal(got, []string{"00000005", "00000015", "00000025"}) {
+			t.Errorf("threads=1: got %v", got)
+		}
+	}
+}
+
+func TestVanitySearcher_SearchEachStopsOnCallbackError(t *testing.T) {
+	cfg := &VanityConfig{Contains: "5", Mode: VanityModeSuffix, CaseSensitive: true, Threads: 2, Count: 10}
+	calls := 0
+	errStop := errors.New("stop")
+	err := NewVanitySearcher(cfg, &countingGenerator{}).SearchEach(context.Background(), func(r *VanityResult) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Fatalf("err = %v after %d calls, want errStop after 1", err, calls)
+	}
+}
+
+func TestVanityMatcher_PatternsAndRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *VanityConfig
+		checksum ChecksumFunc
+		address  string
+		want     bool
+	}{
+		{
+			name:    "any alternative matches",
+			config:  &VanityConfig{Contains: "cafe,beef,f00d", Mode: VanityModePrefix, CaseSensitive: true},
+			address: "beef1234",
+			want:    true,
+		},
+		{
+			name:    "no alternative matches",
+			config:  &VanityConfig{Contains: "cafe,beef,f00d", Mode: VanityModePrefix, CaseSensitive: true},
+			address: "dead1234",
+			want:    false,
+		},
+		{
+			name:    "empty alternatives are ignored",
+			config:  &VanityConfig{Contains: "cafe,,", Mode: VanityModeSuffix, CaseSensitive: true},
+			address: "1234cafe",
+			want:    true,
+		},
+		{
+			name:    "anchored regex",
+			config:  &VanityConfig{Regex: "^0{6}", CaseSensitive: true},
+			address: "000000ab12",
+			want:    true,
+		},
+		{
+			name:    "anchored regex miss",
+			config:  &VanityConfig{Regex: "^0{6}", CaseSensitive: true},
+			address: "00000ab123",
+			want:    false,
+		},
+		{
+			name:    "repeated character idiom",
+			config:  &VanityConfig{Regex: `(.)\1{5}$`, CaseSensitive: true},
+			address: "12abcccccc",
+			want:    true,
+		},
+		{
+			name:    "repeated character idiom needs six",
+			config:  &VanityConfig{Regex: `(.)\1{5}$`, CaseSensitive: true},
+			address: "123accccc",
+			want:    false,
+		},
+		{
+			name:    "regex and contains must both hold",
+			config:  &VanityConfig{Contains: "ab", Mode: VanityModeSuffix, Regex: "^00", CaseSensitive: true},
+			address: "0012ac",
+			want:    false,
+		},
+		{
+			name:     "regex checks the checksum form when case-sensitive",
+			config:   &VanityConfig{Regex: "^[A-F]{3}", CaseSensitive: true},
+			checksum: func(string) string { return "ABcdef" },
+			address:  "abcdef",
+			want:     false,
+		},
+		{
+			name:     "regex insensitive skips the checksum",
+			config:   &VanityConfig{Regex: "^[A-F]{3}"},
+			checksum: func(string) string { panic("checksum computed") },
+			address:  "abcdef",
+			want:     true,
+		},
+		{
+			name:     "checksum computed once across alternatives",
+			config:   &VanityConfig{Contains: "ABx,AB", Mode: VanityModePrefix, CaseSensitive: true},
+			checksum: onceChecksum(t, "ABcdef"),
+			address:  "abcdef",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewVanityMatcher(tt.config)
+			m.checksumFn = tt.checksum
+			if got := m.Match(tt.address); got != tt.want {
+				t.Fatalf("Match(%q) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+// onceChecksum fails the test if the checksum is requested twice for one candidate.
+func onceChecksum(t *testing.T, display string) ChecksumFunc {
+	calls := 0
+	return func(string) string {
+		if calls++; calls > 1 {
+			t.Fatalf("checksum computed %d times", calls)
+		}
+		return display
+	}
+}
+
+func TestCompileVanityRegex_RejectsBackreferences(t *testing.T) {
+	for _, expr := range []string{`(a)(.)\2`, `^(ab)\1`, `(.)\1\1`} {
+		if _, _, err := compileVanityRegex(expr); err == nil {
+			t.Errorf("compileVanityRegex(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestVanityMatcher_Lowercase(t *testing.T) {
+	const address = "bc1qdeadq0l4xk"
+	for _, tc := range []struct {
+		config *VanityConfig
+		want   bool
+	}{
+		{&VanityConfig{Contains: "bc1qDEAD", Mode: VanityModePrefix, CaseSensitive: true}, true},
+		{&VanityConfig{Contains: "Q0L4XK", Mode: VanityModeSuffix, CaseSensitive: true, UpperOrLower: true}, true},
+		{&VanityConfig{Regex: "^BC1QDEAD", CaseSensitive: true}, true},
+		{&VanityConfig{Contains: "beef", Mode: VanityModePrefixOrSuffix, CaseSensitive: true}, false},
+	} {
+		s := NewVanitySearcher(tc.config, nil).WithLowercase()
+		if got := s.Matcher().Match(address); got != tc.want {
+			t.Errorf("contains=%q regex=%q: Match(%s) = %v, want %v", tc.config.Contains, tc.config.Regex, address, got, tc.want)
+		}
+	}
+
+	// Without WithLowercase the same case-sensitive pattern can't match.
+	if NewVanityMatcher(&VanityConfig{Contains: "bc1qDEAD", Mode: VanityModePrefix, CaseSensitive: true}).Match(address) {
+		t.Fatal("case-sensitive match of a lowercase address succeeded without WithLowercase")
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// GeneratorCheckpoint is the resumable position of an AddressGenerator: the
+// base seed it derives candidates from plus how far its counter has advanced.
+//
+// Workers share one generator and its atomic counter, so a single checkpoint
+// covers every thread — the thread count may change between runs.
+type GeneratorCheckpoint struct {
+	Seed    string `json:"seed,omitempty"`   // hex; empty for counter-only generators (CREATE2)
+	Counter uint64 `json:"counter"`          // next counter value to hand out
+	Params  string `json:"params,omitempty"` // generator-specific inputs (deployer, init code hash, ...)
+}
+
+// ResumableGenerator is implemented by generators whose position can be
+// captured and restored. Only these support VanityConfig.StatePath.
+type ResumableGenerator interface {
+	AddressGenerator
+	Checkpoint() GeneratorCheckpoint
+	Restore(cp GeneratorCheckpoint) error
+}
+
+// VanityState is the on-disk form of a checkpointed search.
+//
+// The seed makes every key the search will ever produce recomputable, so the
+// file is as sensitive as the keys themselves and is written 0600.
+type VanityState struct {
+	Generator  string              `json:"generator"` // Go type of the generator, guards against cross-chain resumes
+	Criteria   string              `json:"criteria"`  // matcher inputs, guards against resuming a different pattern
+	Checkpoint GeneratorCheckpoint `json:"checkpoint"`
+	Attempts   uint64              `json:"attempts"`
+	Elapsed    float64             `json:"elapsed_seconds"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// LoadVanityState reads a state file. A missing file is not an error: it
+// returns (nil, nil) so the caller starts a fresh search.
+func LoadVanityState(path string) (*VanityState, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state VanityState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("invalid vanity state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// SaveVanityState writes the state atomically (temp file + rename) so a crash
+// mid-write never leaves a truncated checkpoint behind.
+func SaveVanityState(path string, state *VanityState) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// vanityCriteria fingerprints everything that decides whether an address
+// matches, so a state file is only resumed for the search that wrote it.
+func vanityCriteria(config *VanityConfig) string {
+	return fmt.Sprintf("contains=%s regex=%s mode=%d cs=%t uol=%t mask=%x value=%x",
+		config.Contains, config.Regex, config.Mode, config.CaseSensitive, config.UpperOrLower, config.Mask, config.MaskValue)
+}
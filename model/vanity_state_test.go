@@ -0,0 +1,128 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// countingGenerator emits the zero-padded decimal counter as the "address",
+// which makes resume positions directly observable in the results.
+type countingGenerator struct {
+	counter atomic.Uint64
+}
+
+func (g *countingGenerator) Generate() (string, interface{}, error) {
+	c := g.counter.Add(1) - 1
+	return fmt.Sprintf("%08d", c), c, nil
+}
+
+func (g *countingGenerator) Checkpoint() GeneratorCheckpoint {
+	return GeneratorCheckpoint{Counter: g.counter.Load()}
+}
+
+func (g *countingGenerator) Restore(cp GeneratorCheckpoint) error {
+	g.counter.Store(cp.Counter)
+	return nil
+}
+
+func TestVanityState_LoadMissingFile(t *testing.T) {
+	state, err := LoadVanityState(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || state != nil {
+		t.Fatalf("LoadVanityState(missing) = %v, %v; want nil, nil", state, err)
+	}
+}
+
+func TestVanityState_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	want := &VanityState{
+		Generator:  "*model.countingGenerator",
+		Criteria:   "criteria",
+		Checkpoint: GeneratorCheckpoint{Seed: "ab", Counter: 42, Params: "p"},
+		Attempts:   42,
+		Elapsed:    1.5,
+	}
+	if err := SaveVanityState(path, want); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("state file mode = %o, want 600", perm)
+	}
+	got, err := LoadVanityState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Checkpoint != want.Checkpoint || got.Attempts != want.Attempts || got.Elapsed != want.Elapsed {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+// TestVanitySearcher_ResumeContinuesFromCheckpoint runs a search, then a
+// second one against the same state file: the second must start after the
+// first one's hit instead of re-finding it.
+func TestVanitySearcher_ResumeContinuesFromCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	cfg := &VanityConfig{
+		Contains:      "5",
+		Mode:          VanityModeSuffix,
+		CaseSensitive: true,
+		Threads:       1,
+		StatePath:     path,
+	}
+
+	first, err := NewVanitySearcher(cfg, &countingGenerator{}).Search(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Address != "00000005" {
+		t.Fatalf("first search found %s, want 00000005", first.Address)
+	}
+	state, err := LoadVanityState(path)
+	if err != nil || state == nil {
+		t.Fatalf("state not written: %v", err)
+	}
+	if state.Checkpoint.Counter != 6 || state.Attempts != 6 {
+		t.Fatalf("checkpoint = %+v attempts = %d, want counter 6 / attempts 6", state.Checkpoint, state.Attempts)
+	}
+
+	second, err := NewVanitySearcher(cfg, &countingGenerator{}).Search(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Address != "00000015" {
+		t.Fatalf("resumed search found %s, want 00000015", second.Address)
+	}
+	state, _ = LoadVanityState(path)
+	if state.Attempts != 16 {
+		t.Fatalf("attempts after resume = %d, want 16", state.Attempts)
+	}
+}
+
+func TestVanitySearcher_ResumeRejectsDifferentPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	cfg := &VanityConfig{Contains: "5", Mode: VanityModeSuffix, CaseSensitive: true, Threads: 1, StatePath: path}
+	if _, err := NewVanitySearcher(cfg, &countingGenerator{}).Search(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	other := *cfg
+	other.Contains = "7"
+	if _, err := NewVanitySearcher(&other, &countingGenerator{}).Search(context.Background()); err == nil {
+		t.Fatal("expected resume with a different pattern to fail")
+	}
+}
+
+func TestVanitySearcher_ResumeRequiresResumableGenerator(t *testing.T) {
+	cfg := &VanityConfig{Contains: "5", Mode: VanityModeSuffix, Threads: 1, StatePath: filepath.Join(t.TempDir(), "state.json")}
+	gen := struct{ AddressGenerator }{&countingGenerator{}}
+	if _, err := NewVanitySearcher(cfg, gen).Search(context.Background()); err == nil {
+		t.Fatal("expected non-resumable generator to be rejected")
+	}
+}